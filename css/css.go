@@ -2,7 +2,9 @@
 package css
 
 import (
+	"sort"
 	"strconv"
+	"strings"
 )
 
 type Align string
@@ -252,6 +254,68 @@ func (x Style) Set(src ...Style) {
 	}
 }
 
+// Text returns the style formatted as CSS declarations, as they would appear inside a rule block.
+// Declarations are sorted by property name.
+func (x Style) Text() string {
+	keys := make([]string, 0, len(x))
+	for k := range x {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(propertyName(k) + ": " + x[k] + ";")
+	}
+	return b.String()
+}
+
+// Keyframes returns the text of a @keyframes rule with the given name.
+// Frame keys are keyframe selectors, such as "from", "to" or "50%".
+func Keyframes(name string, frames map[string]Style) string {
+	keys := make([]string, 0, len(frames))
+	for k := range frames {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	o := "@keyframes " + name + " {"
+	for _, k := range keys {
+		o += " " + k + " { " + frames[k].Text() + " }"
+	}
+	return o + " }"
+}
+
+// propertyName converts a JS style property name to its CSS form.
+// Vendor prefixed names, such as webkitTransform, gain a leading dash.
+func propertyName(k string) string {
+	if k == "cssFloat" {
+		return "float"
+	}
+
+	var b strings.Builder
+	for _, prefix := range vendorPrefixes {
+		if rest, ok := strings.CutPrefix(k, prefix); ok && rest != "" && rest[0] >= 'A' && rest[0] <= 'Z' {
+			b.WriteByte('-')
+			break
+		}
+	}
+	for _, r := range k {
+		if r >= 'A' && r <= 'Z' {
+			b.WriteByte('-')
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// lowercase JS forms of vendor prefixes; the capitalized forms, such as WebkitTransform, already convert correctly
+var vendorPrefixes = []string{"moz", "ms", "o", "webkit"}
+
 func side(name, val string, sides ...Side) Style {
 	o := make(Style, len(sides))
 	for _, side := range sides {
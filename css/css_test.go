@@ -0,0 +1,60 @@
+package css
+
+import "testing"
+
+func TestPropertyName(t *testing.T) {
+	cases := []struct {
+		in, out string
+	}{
+		{"color", "color"},
+		{"backgroundColor", "background-color"},
+		{"borderTopLeftRadius", "border-top-left-radius"},
+		{"cssFloat", "float"},
+		{"webkitTransform", "-webkit-transform"},
+		{"WebkitTransform", "-webkit-transform"},
+		{"mozAppearance", "-moz-appearance"},
+		{"msTransform", "-ms-transform"},
+		{"opacity", "opacity"},
+		{"order", "order"},
+	}
+
+	for _, c := range cases {
+		if s := propertyName(c.in); s != c.out {
+			t.Errorf("%s: got %q, want %q", c.in, s, c.out)
+		}
+	}
+}
+
+func TestStyleText(t *testing.T) {
+	cases := []struct {
+		name  string
+		style Style
+		out   string
+	}{
+		{"empty", Style{}, ""},
+		{"single", Style{"color": "red"}, "color: red;"},
+		{"sorted", Style{"width": "1px", "height": "2px", "cssFloat": "left"}, "float: left; height: 2px; width: 1px;"},
+		{"kebab", Style{"backgroundColor": "red", "marginTop": "0"}, "background-color: red; margin-top: 0;"},
+	}
+
+	for _, c := range cases {
+		if s := c.style.Text(); s != c.out {
+			t.Errorf("%s: got %q, want %q", c.name, s, c.out)
+		}
+	}
+}
+
+func TestKeyframes(t *testing.T) {
+	s := Keyframes("fade", map[string]Style{
+		"to":   {"opacity": "1"},
+		"from": {"opacity": "0", "transform": "scale(0.5)"},
+	})
+	const want = "@keyframes fade { from { opacity: 0; transform: scale(0.5); } to { opacity: 1; } }"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	if s := Keyframes("empty", nil); s != "@keyframes empty { }" {
+		t.Errorf("empty: got %q", s)
+	}
+}
@@ -3,7 +3,10 @@ package dom
 import (
 	"errors"
 	"net/url"
+	"strings"
 	"syscall/js"
+
+	"github.com/blitz-frost/wasm"
 )
 
 var (
@@ -11,6 +14,13 @@ var (
 	console  = window.Get("console")
 	doc      = window.Get("document")
 	location = window.Get("location")
+	parser   = window.Get("DOMParser")
+)
+
+// document readiness, lazily initialized
+var (
+	ready  chan struct{}
+	loaded chan struct{}
 )
 
 // ElementById returns the element with the given ID in the document.
@@ -43,6 +53,91 @@ func HandleRemove(event EventName, h Handler) {
 	doc.Call("removeEventListener", string(event), h.f)
 }
 
+// Hash returns the fragment identifier of the current URL, without the leading '#'.
+func Hash() string {
+	s := location.Get("hash").String()
+	if len(s) > 0 {
+		s = s[1:]
+	}
+	return s
+}
+
+// OnHashChange registers fn to be called with the new fragment identifier whenever it changes.
+// The returned function deregisters fn and releases the underlying JS function.
+func OnHashChange(fn func(string)) func() {
+	h := HandlerMake(func(Event) {
+		fn(Hash())
+	})
+	WindowHandle(EventHashChange, h)
+
+	return func() {
+		WindowHandleRemove(EventHashChange, h)
+		h.Delete()
+	}
+}
+
+// OnVisibilityChange registers fn to be called whenever the page is hidden or shown, such as when switching tabs.
+// The returned function deregisters fn and releases the underlying JS function.
+func OnVisibilityChange(fn func(visible bool)) func() {
+	h := HandlerMake(func(Event) {
+		fn(PageVisible())
+	})
+	Handle(EventVisibilityChange, h)
+
+	return func() {
+		HandleRemove(EventVisibilityChange, h)
+		h.Delete()
+	}
+}
+
+// PageVisible returns false if the page is currently hidden, such as when in a background tab or minimized window.
+func PageVisible() bool {
+	return !doc.Get("hidden").Bool()
+}
+
+// ParseHTML parses an HTML string and returns the body of the resulting document.
+// The returned Element is detached from the current document; it or its children may be appended as needed.
+func ParseHTML(s string) (Element, error) {
+	parsed := parser.New().Call("parseFromString", s, "text/html")
+	body := parsed.Get("body")
+	if body.IsNull() {
+		return Element{}, errors.New("no body in parsed HTML")
+	}
+	return Element{body}, nil
+}
+
+// ParseXML parses an XML string and returns the root element of the resulting document.
+// Returns an error if the input is malformed.
+func ParseXML(s string) (js.Value, error) {
+	parsed := parser.New().Call("parseFromString", s, "application/xml")
+
+	// the parser doesn't throw; instead it embeds an error element in the output
+	errs := parsed.Call("getElementsByTagName", "parsererror")
+	if errs.Length() > 0 {
+		return js.Value{}, errors.New(errs.Index(0).Get("textContent").String())
+	}
+
+	return parsed.Get("documentElement"), nil
+}
+
+// Query returns the parsed query parameters of the current URL.
+func Query() url.Values {
+	s := location.Get("search").String()
+	o, _ := url.ParseQuery(strings.TrimPrefix(s, "?"))
+	return o
+}
+
+// Ready returns a channel that is closed once the document has been parsed (DOMContentLoaded).
+// If that has already happened, the channel is closed immediately.
+func Ready() <-chan struct{} {
+	if ready == nil {
+		ready = readyWait(doc, "DOMContentLoaded", func(state string) bool {
+			return state != "loading"
+		})
+	}
+	return ready
+}
+
 // Url returns the current navigation URL.
 func Url() url.URL {
 	s := location.Get("href").String()
@@ -50,6 +145,17 @@ func Url() url.URL {
 	return *u
 }
 
+// WindowLoaded returns a channel that is closed once the page and all its resources, such as images and stylesheets, have loaded.
+// If that has already happened, the channel is closed immediately.
+func WindowLoaded() <-chan struct{} {
+	if loaded == nil {
+		loaded = readyWait(window, "load", func(state string) bool {
+			return state == "complete"
+		})
+	}
+	return loaded
+}
+
 func WindowHandle(event EventName, h Handler) {
 	window.Call("addEventListener", string(event), h.f)
 }
@@ -58,6 +164,26 @@ func WindowHandleRemove(event EventName, h Handler) {
 	window.Call("removeEventListener", string(event), h.f)
 }
 
+// readyWait returns a channel that is closed when target fires event, or immediately if done reports the current document
+// readyState as already past it.
+func readyWait(target js.Value, event string, done func(string) bool) chan struct{} {
+	o := make(chan struct{})
+	if done(doc.Get("readyState").String()) {
+		close(o)
+		return o
+	}
+
+	var f js.Func
+	f = wasm.FuncOf(func(this js.Value, args []js.Value) any {
+		close(o)
+		f.Release()
+		return nil
+	})
+	target.Call("addEventListener", event, f, map[string]any{"once": true})
+
+	return o
+}
+
 /*
 //TODO update along with jsconv package
 // Log wraps the standard package fmt.Println.
@@ -4,6 +4,7 @@ package dom
 import (
 	"syscall/js"
 
+	"github.com/blitz-frost/wasm"
 	"github.com/blitz-frost/wasm/css"
 )
 
@@ -27,6 +28,11 @@ func (x Element) Add(pos int, e ...Base) {
 	}
 }
 
+// Adopt adds the given style sheets to x, which must be a shadow root.
+func (x Element) Adopt(sheet ...StyleSheet) {
+	adopt(x.Value, sheet)
+}
+
 // Append adds the given elements as final subelement.
 func (x Element) Append(e ...Base) {
 	for _, b := range e {
@@ -34,6 +40,13 @@ func (x Element) Append(e ...Base) {
 	}
 }
 
+// AttachShadow attaches a shadow root to x and returns it.
+// mode is either "open" or "closed".
+// Subelements appended to the shadow root are encapsulated from the rest of the document, including styles.
+func (x Element) AttachShadow(mode string) Element {
+	return Element{x.Call("attachShadow", map[string]any{"mode": mode})}
+}
+
 func (x Element) Class() string {
 	return x.Get("className").String()
 }
@@ -42,12 +55,48 @@ func (x Element) ClassSet(name string) {
 	x.Set("className", name)
 }
 
+// Clone returns a copy of x. If deep is true, the copy includes all subelements.
+// The copy is not attached to the document. Event handlers registered on x are not copied.
+func (x Element) Clone(deep bool) Element {
+	return Element{x.Call("cloneNode", deep)}
+}
+
 // Delete removes the subelement at index i.
 func (x Element) Delete(i int) {
 	sub := x.Get("children").Index(i)
 	sub.Call("remove")
 }
 
+// DispatchInput sets the value of x and dispatches a bubbling input event on it, as if typed by the user.
+func (x Element) DispatchInput(value string) {
+	x.Set("value", value)
+	ev := window.Get("InputEvent").New(string(EventInput), map[string]any{
+		"bubbles":   true,
+		"data":      value,
+		"inputType": "insertText",
+	})
+	x.Call("dispatchEvent", ev)
+}
+
+// DispatchKey dispatches a bubbling keyboard event on x.
+func (x Element) DispatchKey(key string, opts KeyOptions) {
+	name := opts.Event
+	if name == "" {
+		name = EventKeyDown
+	}
+
+	ev := window.Get("KeyboardEvent").New(string(name), map[string]any{
+		"bubbles":  true,
+		"key":      key,
+		"code":     opts.Code,
+		"altKey":   opts.Alt,
+		"ctrlKey":  opts.Ctrl,
+		"metaKey":  opts.Meta,
+		"shiftKey": opts.Shift,
+	})
+	x.Call("dispatchEvent", ev)
+}
+
 func (x Element) EditableSet(t bool) {
 	x.Set("contentEditable", t)
 }
@@ -79,11 +128,21 @@ func (x Element) Handle(event EventName, h Handler) {
 	x.Call("addEventListener", string(event), h.f)
 }
 
+// HandleOpts is like Handle, with additional listener options.
+func (x Element) HandleOpts(event EventName, h Handler, opts ListenerOptions) {
+	x.Call("addEventListener", string(event), h.f, opts.js())
+}
+
 // HandleRemove unsubscribes the given Handler from the specified event.
 func (x Element) HandleRemove(event EventName, h Handler) {
 	x.Call("removeEventListener", string(event), h.f)
 }
 
+// HandleRemoveOpts unsubscribes a Handler subscribed through HandleOpts. Only the Capture option is relevant.
+func (x Element) HandleRemoveOpts(event EventName, h Handler, opts ListenerOptions) {
+	x.Call("removeEventListener", string(event), h.f, map[string]any{"capture": opts.Capture})
+}
+
 func (x Element) Height() uint16 {
 	return uint16(x.Get("offsetHeight").Int())
 }
@@ -100,6 +159,11 @@ func (x Element) IdSet(id string) {
 	x.Set("id", id)
 }
 
+// InnerHTML returns the serialized markup of the subelements of x.
+func (x Element) InnerHTML() string {
+	return x.Get("innerHTML").String()
+}
+
 func (x Element) Kind() ElementKind {
 	return ElementKind(x.Get("tagName").String())
 }
@@ -115,6 +179,11 @@ func (x Element) Next() Element {
 	return Element{x.Get("nextElementSibling")}
 }
 
+// OuterHTML returns the serialized markup of x, including x itself.
+func (x Element) OuterHTML() string {
+	return x.Get("outerHTML").String()
+}
+
 // Previous returns the previous element in the same node.
 // Returns an empty Element if there is none.
 func (x Element) Previous() Element {
@@ -133,10 +202,53 @@ func (x Element) RemoveSelf() {
 	x.Call("remove")
 }
 
+// OnAttributeChange calls fn whenever the named attribute of x changes. Missing attribute values are reported as empty strings.
+// The returned function stops observing and releases the underlying JS function.
+func (x Element) OnAttributeChange(name string, fn func(oldVal, newVal string)) func() {
+	f := wasm.FuncOf(func(this js.Value, args []js.Value) any {
+		// records may be batched; each new value is the following record's old value
+		records := args[0]
+		n := records.Length()
+		for i := 0; i < n; i++ {
+			oldVal := records.Index(i).Get("oldValue")
+			var newVal js.Value
+			if i+1 < n {
+				newVal = records.Index(i + 1).Get("oldValue")
+			} else {
+				newVal = x.Call("getAttribute", name)
+			}
+			fn(attributeString(oldVal), attributeString(newVal))
+		}
+		return nil
+	})
+
+	observer := window.Get("MutationObserver").New(f)
+	observer.Call("observe", x.Value, map[string]any{
+		"attributes":        true,
+		"attributeFilter":   []any{name},
+		"attributeOldValue": true,
+	})
+
+	return func() {
+		observer.Call("disconnect")
+		f.Release()
+	}
+}
+
 func (x Element) Replace(newElem, oldElem Base) {
 	x.Call("replaceChild", newElem.Base().Value, oldElem.Base().Value)
 }
 
+// ShadowRoot returns the open shadow root attached to x.
+// Returns false if there is none, or if it is closed.
+func (x Element) ShadowRoot() (Element, bool) {
+	v := x.Get("shadowRoot")
+	if v.IsNull() {
+		return Element{}, false
+	}
+	return Element{v}, true
+}
+
 func (x Element) SpellcheckSet(val bool) {
 	x.Set("spellcheck", val)
 }
@@ -191,6 +303,13 @@ func (x Element) WidthVisible() uint16 {
 	return uint16(x.Get("clientWidth").Int())
 }
 
+func attributeString(v js.Value) string {
+	if v.IsNull() {
+		return ""
+	}
+	return v.String()
+}
+
 func (x Element) Base() Element {
 	return x
 }
@@ -4,15 +4,73 @@ package elements
 import (
 	"syscall/js"
 
+	"github.com/blitz-frost/wasm"
 	"github.com/blitz-frost/wasm/dom"
 	"github.com/blitz-frost/wasm/media"
+	"github.com/blitz-frost/wasm/webgl"
 )
 
+// ClassInvalid is the class toggled on inputs that fail validation.
+const ClassInvalid = "invalid"
+
 var global = js.Global()
 var doc = global.Get("document")
 
 type Element = dom.Element
 
+// Make returns a new element of the given tag, for tags not covered by this package.
+func Make(tag string) Element {
+	return Element{doc.Call("createElement", tag)}
+}
+
+type Audio struct {
+	Element
+}
+
+func MakeAudio() Audio {
+	return Audio{Make("audio")}
+}
+
+func (x Audio) Muted() bool {
+	return x.Get("muted").Bool()
+}
+
+func (x Audio) MutedSet(v bool) {
+	x.Set("muted", v)
+}
+
+// OnEnded registers fn to be called when playback reaches the end.
+// The returned function deregisters fn and releases the underlying JS function.
+func (x Audio) OnEnded(fn func()) func() {
+	return onEnded(x.Element, fn)
+}
+
+func (x Audio) Pause() {
+	x.Call("pause")
+}
+
+// Play starts playback. Browsers may refuse to play unmuted audio before the user has interacted with the page.
+func (x Audio) Play() {
+	x.Call("play")
+}
+
+func (x Audio) SourceUrl() string {
+	return x.Get("src").String()
+}
+
+func (x Audio) SourceUrlSet(url string) {
+	x.Set("src", url)
+}
+
+func (x Audio) Volume() float64 {
+	return x.Get("volume").Float()
+}
+
+// VolumeSet sets the playback volume, clamped to [0, 1].
+func (x Audio) VolumeSet(v float64) {
+	volumeSet(x.Element, v)
+}
+
 type Button struct {
 	Element
 }
@@ -21,6 +79,30 @@ func MakeButton() Button {
 	return Button{Element{doc.Call("createElement", "button")}}
 }
 
+type Canvas struct {
+	Element
+}
+
+func MakeCanvas() Canvas {
+	return Canvas{Make("canvas")}
+}
+
+// ContextWebGL returns the WebGL rendering context of the canvas.
+// Returns an error if WebGL is not available, or if the canvas already has a context of a different kind.
+func (x Canvas) ContextWebGL() (*webgl.GL, error) {
+	return webgl.AsGL(x.Call("getContext", "webgl"))
+}
+
+// HeightSet sets the height of the drawing buffer in pixels, independent of the displayed size.
+func (x Canvas) HeightSet(n int) {
+	x.Set("height", n)
+}
+
+// WidthSet sets the width of the drawing buffer in pixels, independent of the displayed size.
+func (x Canvas) WidthSet(n int) {
+	x.Set("width", n)
+}
+
 // A Cell wraps a DOM td
 type Cell struct {
 	Element
@@ -80,6 +162,66 @@ func (x Checkbox) ExplicitSet(v bool) {
 	x.Set("indeterminate", !v)
 }
 
+type Details struct {
+	Element
+}
+
+func MakeDetails() Details {
+	return Details{Make("details")}
+}
+
+// Open returns true if the details are currently expanded.
+func (x Details) Open() bool {
+	return x.Get("open").Bool()
+}
+
+func (x Details) OpenSet(v bool) {
+	x.Set("open", v)
+}
+
+// A Dialog wraps a DOM dialog, the standard modal primitive.
+type Dialog struct {
+	Element
+}
+
+func MakeDialog() Dialog {
+	return Dialog{Make("dialog")}
+}
+
+func (x Dialog) Close() {
+	x.Call("close")
+}
+
+// OnClose registers fn to be called whenever the dialog closes, including through the Escape key.
+// The returned function deregisters fn and releases the underlying JS function.
+func (x Dialog) OnClose(fn func()) func() {
+	h := dom.HandlerMake(func(dom.Event) {
+		fn()
+	})
+	x.Handle(dom.EventClose, h)
+
+	return func() {
+		x.HandleRemove(dom.EventClose, h)
+		h.Delete()
+	}
+}
+
+func (x Dialog) Open() bool {
+	return x.Get("open").Bool()
+}
+
+// Show displays the dialog without blocking interaction with the rest of the page.
+func (x Dialog) Show() {
+	x.Call("show")
+}
+
+// ShowModal displays the dialog on top of the page, which becomes inert until the dialog closes.
+// The dialog must be attached to the document.
+func (x Dialog) ShowModal() error {
+	_, err := wasm.Call(x.Value, "showModal")
+	return err
+}
+
 type Div struct {
 	Element
 }
@@ -131,6 +273,30 @@ func MakePara() Para {
 	return Para{Element{doc.Call("createElement", "p")}}
 }
 
+type Progress struct {
+	Element
+}
+
+func MakeProgress() Progress {
+	return Progress{Make("progress")}
+}
+
+func (x Progress) Max() float64 {
+	return x.Get("max").Float()
+}
+
+func (x Progress) MaxSet(v float64) {
+	x.Set("max", v)
+}
+
+func (x Progress) Value() float64 {
+	return x.Get("value").Float()
+}
+
+func (x Progress) ValueSet(v float64) {
+	x.Set("value", v)
+}
+
 // A Row wraps a DOM tr
 type Row struct {
 	Element
@@ -286,6 +452,76 @@ func (x TextArea) TextSet(s string) {
 	x.Set("value", s)
 }
 
+// A ValidatedInput is a text input paired with a message paragraph, both wrapped in a div.
+// User input is validated once it settles for a given delay. On failure, the input gets the [ClassInvalid] class and the message shows the error.
+type ValidatedInput struct {
+	Div
+	Input   Element
+	Message Para
+
+	fn    func(string) error
+	err   error
+	delay uint64
+
+	h     dom.Handler
+	timer wasm.Timer
+}
+
+// MakeValidatedInput returns a ValidatedInput that runs fn delay milliseconds after the last user input.
+func MakeValidatedInput(delay uint64, fn func(string) error) *ValidatedInput {
+	x := &ValidatedInput{
+		Div:     MakeDiv(),
+		Input:   Element{doc.Call("createElement", "input")},
+		Message: MakePara(),
+		fn:      fn,
+		delay:   delay,
+	}
+	x.Append(x.Input, x.Message)
+
+	x.h = dom.HandlerMake(func(dom.Event) {
+		x.timer.Stop()
+		x.timer = wasm.TimerMake(x.delay, x.Validate)
+	})
+	x.Input.Handle(dom.EventInput, x.h)
+
+	return x
+}
+
+// Err returns the result of the last validation.
+func (x *ValidatedInput) Err() error {
+	return x.err
+}
+
+// Release deregisters the input handler and cancels any pending validation.
+func (x *ValidatedInput) Release() {
+	x.timer.Stop()
+	x.Input.HandleRemove(dom.EventInput, x.h)
+	x.h.Delete()
+}
+
+// Validate immediately validates the current value and updates the displayed state.
+func (x *ValidatedInput) Validate() {
+	x.timer.Stop()
+	x.err = x.fn(x.Value())
+
+	msg := ""
+	if x.err != nil {
+		msg = x.err.Error()
+	}
+	x.Input.Get("classList").Call("toggle", ClassInvalid, x.err != nil)
+	x.Message.Set("textContent", msg)
+}
+
+func (x *ValidatedInput) Value() string {
+	return x.Input.Get("value").String()
+}
+
+// ValueSet sets the input value and validates it.
+func (x *ValidatedInput) ValueSet(s string) {
+	x.Input.Set("value", s)
+	x.Validate()
+}
+
 type Video struct {
 	Element
 }
@@ -310,6 +546,47 @@ func (x Video) MutedSet(v bool) {
 	x.Set("muted", v)
 }
 
+// OnFrame calls fn every time a video frame is presented, with the frame timestamp in milliseconds and the frame metadata
+// (presentedFrames, mediaTime, etc.). Comparing consecutive presentedFrames values reveals dropped frames.
+// The returned function stops the callbacks and releases the underlying JS function.
+func (x Video) OnFrame(fn func(now float64, metadata js.Value)) func() {
+	var (
+		f    js.Func
+		id   js.Value
+		done bool
+	)
+
+	f = wasm.FuncOf(func(this js.Value, args []js.Value) any {
+		if done {
+			return nil
+		}
+
+		fn(args[0].Float(), args[1])
+		// fn may have stopped the callback, releasing f
+		if done {
+			return nil
+		}
+		id = x.Call("requestVideoFrameCallback", f)
+		return nil
+	})
+	id = x.Call("requestVideoFrameCallback", f)
+
+	return func() {
+		if done {
+			return
+		}
+		done = true
+		x.Call("cancelVideoFrameCallback", id)
+		f.Release()
+	}
+}
+
+// OnEnded registers fn to be called when playback reaches the end.
+// The returned function deregisters fn and releases the underlying JS function.
+func (x Video) OnEnded(fn func()) func() {
+	return onEnded(x.Element, fn)
+}
+
 func (x Video) SourceStream() media.Stream {
 	v := x.Get("srcObject")
 	return media.AsStream(v)
@@ -327,3 +604,33 @@ func (x Video) SourceUrl() string {
 func (x Video) SourceUrlSet(url string) {
 	x.Set("src", url)
 }
+
+func (x Video) Volume() float64 {
+	return x.Get("volume").Float()
+}
+
+// VolumeSet sets the playback volume, clamped to [0, 1].
+func (x Video) VolumeSet(v float64) {
+	volumeSet(x.Element, v)
+}
+
+func onEnded(x Element, fn func()) func() {
+	h := dom.HandlerMake(func(dom.Event) {
+		fn()
+	})
+	x.Handle(dom.EventEnded, h)
+
+	return func() {
+		x.HandleRemove(dom.EventEnded, h)
+		h.Delete()
+	}
+}
+
+func volumeSet(x Element, v float64) {
+	if v < 0 {
+		v = 0
+	} else if v > 1 {
+		v = 1
+	}
+	x.Set("volume", v)
+}
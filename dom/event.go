@@ -2,30 +2,79 @@ package dom
 
 import (
 	"syscall/js"
+
+	"github.com/blitz-frost/wasm"
 )
 
 type EventName string
 
 const (
-	EventBlur       EventName = "blur"
-	EventChange               = "change"
-	EventClick                = "click"
-	EventClickRight           = "contextmenu"
-	EventFocus                = "focus"
-	EventFocusIn              = "focusin"
-	EventFocusOut             = "focusout"
-	EventInput                = "input"
-	EventKeyDown              = "keydown"
-	EventKeyUp                = "keyup"
-	EventMouseDown            = "mousedown"
-	EventMouseEnter           = "mouseenter"
-	EventMouseLeave           = "mouseleave"
-	EventMouseMove            = "mousemove"
-	EventMouseUp              = "mouseup"
-	EventMouseWheel           = "mousewheel"
-	EventResize               = "resize"
+	EventBlur             EventName = "blur"
+	EventChange                     = "change"
+	EventClick                      = "click"
+	EventClickRight                 = "contextmenu"
+	EventClose                      = "close"
+	EventCopy                       = "copy"
+	EventCut                        = "cut"
+	EventDragEnd                    = "dragend"
+	EventDragOver                   = "dragover"
+	EventDragStart                  = "dragstart"
+	EventDrop                       = "drop"
+	EventEnded                      = "ended"
+	EventFocus                      = "focus"
+	EventFocusIn                    = "focusin"
+	EventFocusOut                   = "focusout"
+	EventHashChange                 = "hashchange"
+	EventInput                      = "input"
+	EventKeyDown                    = "keydown"
+	EventKeyUp                      = "keyup"
+	EventMouseDown                  = "mousedown"
+	EventMouseEnter                 = "mouseenter"
+	EventMouseLeave                 = "mouseleave"
+	EventMouseMove                  = "mousemove"
+	EventMouseUp                    = "mouseup"
+	EventMouseWheel                 = "mousewheel"
+	EventPaste                      = "paste"
+	EventPointerDown                = "pointerdown"
+	EventResize                     = "resize"
+	EventVisibilityChange           = "visibilitychange"
 )
 
+// A ClipboardEvent is dispatched on copy, cut and paste.
+// To replace the copied data, set it through ClipboardData and cancel the default behaviour.
+type ClipboardEvent struct {
+	Event
+}
+
+func (x ClipboardEvent) ClipboardData() DataTransfer {
+	return DataTransfer{x.Get("clipboardData")}
+}
+
+// A DataTransfer wraps data being copied, pasted or dragged.
+type DataTransfer struct {
+	js.Value
+}
+
+// Files returns the files being transferred, such as pasted images.
+func (x DataTransfer) Files() []File {
+	files := x.Get("files")
+	o := make([]File, files.Length())
+	for i := range o {
+		o[i] = File{files.Index(i)}
+	}
+	return o
+}
+
+// Data returns the data of the given format, such as "text/plain" or "text/html".
+// Returns an empty string if there is no data of that format.
+func (x DataTransfer) Data(format string) string {
+	return x.Call("getData", format).String()
+}
+
+func (x DataTransfer) DataSet(format, data string) {
+	x.Call("setData", format, data)
+}
+
 // An Event wraps a JS event object
 type Event struct {
 	js.Value
@@ -43,6 +92,44 @@ func (x Event) Target() Element {
 	return Element{x.Get("target")}
 }
 
+// A File wraps a JS File object.
+type File struct {
+	js.Value
+}
+
+func (x File) Name() string {
+	return x.Get("name").String()
+}
+
+// Size returns the file size in bytes.
+func (x File) Size() int {
+	return x.Get("size").Int()
+}
+
+// Type returns the MIME type of the file, if known.
+func (x File) Type() string {
+	return x.Get("type").String()
+}
+
+// An InputEvent is dispatched on EventInput, when the content of an editable element changes.
+type InputEvent struct {
+	Event
+}
+
+// Data returns the inserted text. Empty for deletions and formatting changes.
+func (x InputEvent) Data() string {
+	v := x.Get("data")
+	if v.Type() != js.TypeString {
+		return ""
+	}
+	return v.String()
+}
+
+// InputType returns the kind of change, such as "insertText", "insertFromPaste" or "deleteContentBackward".
+func (x InputEvent) InputType() string {
+	return x.Get("inputType").String()
+}
+
 type KeyboardEvent struct {
 	Event
 }
@@ -60,6 +147,31 @@ func (x KeyboardEvent) Key() string {
 	return x.Get("key").String()
 }
 
+// KeyOptions configures synthetic keyboard events.
+type KeyOptions struct {
+	Event EventName // defaults to EventKeyDown
+	Code  string
+	Alt   bool
+	Ctrl  bool
+	Meta  bool
+	Shift bool
+}
+
+// ListenerOptions configures event listeners.
+type ListenerOptions struct {
+	Capture bool // handle the event on the way down to its target, before subelement listeners
+	Once    bool // remove the listener after it first fires; the Handler must still be deleted separately
+	Passive bool // promise not to cancel the default action, allowing smooth scrolling on touch devices
+}
+
+func (x ListenerOptions) js() map[string]any {
+	return map[string]any{
+		"capture": x.Capture,
+		"once":    x.Once,
+		"passive": x.Passive,
+	}
+}
+
 type MouseEvent struct {
 	Event
 }
@@ -101,12 +213,19 @@ type Handler struct {
 // fn must be non blocking, otherwise the application will deadlock.
 // Notably, http requests block.
 func HandlerMake(fn func(Event)) Handler {
-	return Handler{js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+	return Handler{wasm.FuncOf(func(this js.Value, args []js.Value) interface{} {
 		fn(Event{args[0]})
 		return nil
 	})}
 }
 
+// InputHandlerMake is like HandlerMake, for EventInput handlers.
+func InputHandlerMake(fn func(InputEvent)) Handler {
+	return HandlerMake(func(ev Event) {
+		fn(InputEvent{ev})
+	})
+}
+
 // Delete releases the underlying JS function.
 func (x Handler) Delete() {
 	x.f.Release()
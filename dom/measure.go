@@ -0,0 +1,104 @@
+package dom
+
+import (
+	"syscall/js"
+
+	"github.com/blitz-frost/wasm"
+)
+
+// batch state
+var (
+	measures  []func(*Reader)
+	mutations []func()
+	flushFn   js.Func
+	scheduled bool
+)
+
+// A Reader performs layout reads during a [Measure] batch.
+// Results are cached for the duration of the batch, since no writes can occur in between.
+type Reader struct {
+	elems []js.Value
+	rects []Rect
+}
+
+// Height returns the rendered height of elem, including borders and padding.
+func (x *Reader) Height(elem Base) float64 {
+	return x.Rect(elem).Height
+}
+
+// Rect returns the bounding rectangle of elem, relative to the viewport.
+func (x *Reader) Rect(elem Base) Rect {
+	v := elem.Base().Value
+	for i, e := range x.elems {
+		if e.Equal(v) {
+			return x.rects[i]
+		}
+	}
+
+	r := v.Call("getBoundingClientRect")
+	o := Rect{
+		X:      r.Get("x").Float(),
+		Y:      r.Get("y").Float(),
+		Width:  r.Get("width").Float(),
+		Height: r.Get("height").Float(),
+	}
+	x.elems = append(x.elems, v)
+	x.rects = append(x.rects, o)
+	return o
+}
+
+// Width returns the rendered width of elem, including borders and padding.
+func (x *Reader) Width(elem Base) float64 {
+	return x.Rect(elem).Width
+}
+
+type Rect struct {
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+}
+
+// Measure schedules fn to run on the next animation frame, together with all other measurements, before any [Mutate] functions.
+// Grouping layout reads and writes this way avoids forcing repeated synchronous layouts.
+// fn must not modify the DOM.
+func Measure(fn func(*Reader)) {
+	measures = append(measures, fn)
+	schedule()
+}
+
+// Mutate schedules fn to run on the next animation frame, after all [Measure] functions.
+func Mutate(fn func()) {
+	mutations = append(mutations, fn)
+	schedule()
+}
+
+func flush() {
+	scheduled = false
+
+	m, w := measures, mutations
+	measures, mutations = nil, nil
+
+	r := &Reader{}
+	for _, fn := range m {
+		fn(r)
+	}
+	for _, fn := range w {
+		fn()
+	}
+}
+
+func schedule() {
+	if scheduled {
+		return
+	}
+	scheduled = true
+
+	if flushFn.Value.IsUndefined() {
+		flushFn = wasm.FuncOf(func(this js.Value, args []js.Value) any {
+			flush()
+			return nil
+		})
+	}
+	window.Call("requestAnimationFrame", flushFn)
+}
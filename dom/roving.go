@@ -0,0 +1,67 @@
+package dom
+
+// RovingTabindex makes the subelements of container a single tab stop, navigable with the arrow keys, as recommended by the
+// ARIA authoring practices for menus, listboxes and toolbars.
+// Only the current subelement has a tabindex of 0; all others have -1. Arrow keys move focus to the next or previous
+// subelement, wrapping around at the ends, while Home and End jump to the first and last one.
+// The returned function deregisters the handlers and releases the underlying JS functions.
+func RovingTabindex(container Base) func() {
+	elem := container.Base()
+
+	current := 0
+	set := func(i int) {
+		n := elem.Len()
+		for j := 0; j < n; j++ {
+			if j == i {
+				elem.Sub(j).TabIndexSet(0)
+			} else {
+				elem.Sub(j).TabIndexSet(-1)
+			}
+		}
+		current = i
+	}
+	set(current)
+
+	key := HandlerMake(func(ev Event) {
+		n := elem.Len()
+		if n == 0 {
+			return
+		}
+
+		next := current
+		k := KeyboardEvent{ev}
+		switch k.Key() {
+		case "ArrowDown", "ArrowRight":
+			next = (current + 1) % n
+		case "ArrowUp", "ArrowLeft":
+			next = (current - 1 + n) % n
+		case "Home":
+			next = 0
+		case "End":
+			next = n - 1
+		default:
+			return
+		}
+
+		ev.CancelDefault()
+		set(next)
+		elem.Sub(next).FocusSet(true)
+	})
+
+	// keep track of subelements focused by other means, such as clicking
+	focus := HandlerMake(func(ev Event) {
+		if i := childIndex(elem, ev.Get("target")); i >= 0 && i != current {
+			set(i)
+		}
+	})
+
+	elem.Handle(EventKeyDown, key)
+	elem.Handle(EventFocusIn, focus)
+
+	return func() {
+		elem.HandleRemove(EventKeyDown, key)
+		elem.HandleRemove(EventFocusIn, focus)
+		key.Delete()
+		focus.Delete()
+	}
+}
@@ -0,0 +1,113 @@
+package dom
+
+import (
+	"syscall/js"
+)
+
+// Sortable lets the user reorder the subelements of container by dragging them.
+// After each completed reorder, the DOM is updated and onReorder is called with the initial and final index of the moved subelement.
+// Subelements added after the call are also sortable.
+// The returned function disables sorting and releases the underlying JS functions.
+func Sortable(container Base, onReorder func(from, to int)) func() {
+	elem := container.Base()
+
+	var (
+		dragged Element
+		from    int
+		opacity string
+	)
+
+	down := HandlerMake(func(ev Event) {
+		if i := childIndex(elem, ev.Get("target")); i >= 0 {
+			elem.Sub(i).Set("draggable", true)
+		}
+	})
+	start := HandlerMake(func(ev Event) {
+		from = childIndex(elem, ev.Get("target"))
+		if from < 0 {
+			return
+		}
+		dragged = elem.Sub(from)
+
+		// Firefox won't start dragging without data
+		transfer := ev.Get("dataTransfer")
+		transfer.Call("setData", "text/plain", "")
+		transfer.Set("effectAllowed", "move")
+
+		style := dragged.Get("style")
+		opacity = style.Get("opacity").String()
+		style.Set("opacity", "0.5")
+	})
+	over := HandlerMake(func(ev Event) {
+		if dragged.Value.IsUndefined() {
+			return
+		}
+		// dropping is only allowed if the default is prevented
+		ev.CancelDefault()
+	})
+	drop := HandlerMake(func(ev Event) {
+		if dragged.Value.IsUndefined() {
+			return
+		}
+		ev.CancelDefault()
+
+		to := childIndex(elem, ev.Get("target"))
+		if to < 0 || to == from {
+			return
+		}
+
+		target := elem.Sub(to)
+		if from < to {
+			elem.Call("insertBefore", dragged.Value, target.Get("nextElementSibling"))
+		} else {
+			elem.Call("insertBefore", dragged.Value, target.Value)
+		}
+		onReorder(from, to)
+	})
+	end := HandlerMake(func(ev Event) {
+		if dragged.Value.IsUndefined() {
+			return
+		}
+		dragged.Get("style").Set("opacity", opacity)
+		dragged = Element{}
+	})
+
+	elem.Handle(EventPointerDown, down)
+	elem.Handle(EventDragStart, start)
+	elem.Handle(EventDragOver, over)
+	elem.Handle(EventDrop, drop)
+	elem.Handle(EventDragEnd, end)
+
+	return func() {
+		elem.HandleRemove(EventPointerDown, down)
+		elem.HandleRemove(EventDragStart, start)
+		elem.HandleRemove(EventDragOver, over)
+		elem.HandleRemove(EventDrop, drop)
+		elem.HandleRemove(EventDragEnd, end)
+
+		down.Delete()
+		start.Delete()
+		over.Delete()
+		drop.Delete()
+		end.Delete()
+	}
+}
+
+// childIndex returns the index of the direct subelement of x that contains node.
+// Returns -1 if node is not inside x.
+func childIndex(x Element, node js.Value) int {
+	for !node.IsNull() && !node.IsUndefined() {
+		parent := node.Get("parentElement")
+		if parent.Equal(x.Value) {
+			children := x.Get("children")
+			for i, n := 0, children.Length(); i < n; i++ {
+				if children.Index(i).Equal(node) {
+					return i
+				}
+			}
+			return -1
+		}
+		node = parent
+	}
+	return -1
+}
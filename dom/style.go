@@ -0,0 +1,64 @@
+package dom
+
+import (
+	"syscall/js"
+
+	"github.com/blitz-frost/wasm"
+	"github.com/blitz-frost/wasm/css"
+)
+
+// A StyleSheet wraps a constructable CSSStyleSheet, which can be shared between the document and shadow roots.
+type StyleSheet struct {
+	v js.Value
+}
+
+func StyleSheetMake() StyleSheet {
+	return StyleSheet{window.Get("CSSStyleSheet").New()}
+}
+
+func (x StyleSheet) Js() js.Value {
+	return x.v
+}
+
+// Replace replaces the contents of x with the given CSS text.
+// Must not be called from the event loop.
+func (x StyleSheet) Replace(s string) error {
+	_, err := wasm.CallAwait(x.v, "replace", s)
+	return err
+}
+
+// Adopt adds the given style sheets to the document.
+func Adopt(sheet ...StyleSheet) {
+	adopt(doc, sheet)
+}
+
+func adopt(target js.Value, sheets []StyleSheet) {
+	vals := make([]any, len(sheets))
+	for i, s := range sheets {
+		vals[i] = s.v
+	}
+	current := target.Get("adoptedStyleSheets")
+	target.Set("adoptedStyleSheets", current.Call("concat", vals))
+}
+
+// sheet holds rules inserted by this package. Lazily created on first use.
+var sheet js.Value
+
+func sheetGet() js.Value {
+	if sheet.IsUndefined() {
+		elem := doc.Call("createElement", "style")
+		doc.Get("head").Call("appendChild", elem)
+		sheet = elem.Get("sheet")
+	}
+	return sheet
+}
+
+// Keyframes inserts a @keyframes rule into the document and returns its name, for use in animation styles.
+func Keyframes(name string, frames map[string]css.Style) (string, error) {
+	rule := css.Keyframes(name, frames)
+	s := sheetGet()
+	if _, err := wasm.Call(s, "insertRule", rule, s.Get("cssRules").Length()); err != nil {
+		return "", err
+	}
+	return name, nil
+}
@@ -0,0 +1,96 @@
+package dom
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"syscall/js"
+	"time"
+
+	"github.com/blitz-frost/wasm"
+)
+
+// AwaitTransition blocks until the CSS transition of the given property on x ends.
+// An empty property matches any transition.
+// As a fallback, in case the event never fires (for example because the transition was interrupted), it returns an error once the
+// computed transition duration and delay have elapsed with some margin. If x has no transition, it returns immediately.
+// Must not be called from the event loop.
+func (x Element) AwaitTransition(property string) error {
+	if wasm.OnEventLoop() {
+		return wasm.ErrEventLoop
+	}
+
+	style := window.Call("getComputedStyle", x.Value)
+	d := transitionMax(style.Get("transitionDuration").String(), style.Get("transitionDelay").String())
+	if d == 0 {
+		return nil
+	}
+
+	ch := make(chan struct{}, 1)
+	f := js.FuncOf(func(this js.Value, args []js.Value) any {
+		if property == "" || args[0].Get("propertyName").String() == property {
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+		return nil
+	})
+	x.Call("addEventListener", "transitionend", f)
+	defer func() {
+		x.Call("removeEventListener", "transitionend", f)
+		f.Release()
+	}()
+
+	t := time.NewTimer(d + 50*time.Millisecond)
+	defer t.Stop()
+
+	select {
+	case <-ch:
+		return nil
+	case <-t.C:
+		return errors.New("transition did not end in time")
+	}
+}
+
+// transitionMax returns the longest duration + delay out of computed transition lists, such as "0.3s, 200ms".
+// Lists of different length are repeated as needed, as in CSS.
+func transitionMax(durations, delays string) time.Duration {
+	dur := strings.Split(durations, ",")
+	del := strings.Split(delays, ",")
+
+	n := len(dur)
+	if len(del) > n {
+		n = len(del)
+	}
+
+	var o time.Duration
+	for i := 0; i < n; i++ {
+		d := cssTime(dur[i%len(dur)]) + cssTime(del[i%len(del)])
+		if d > o {
+			o = d
+		}
+	}
+	return o
+}
+
+// cssTime parses a CSS time value. Returns 0 on invalid input.
+func cssTime(s string) time.Duration {
+	s = strings.TrimSpace(s)
+
+	var unit time.Duration
+	if v, ok := strings.CutSuffix(s, "ms"); ok {
+		s, unit = v, time.Millisecond
+	} else if v, ok := strings.CutSuffix(s, "s"); ok {
+		s, unit = v, time.Second
+	} else {
+		// unitless values are invalid
+		return 0
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(f * float64(unit))
+}
@@ -0,0 +1,57 @@
+package dom
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCssTime(t *testing.T) {
+	cases := []struct {
+		in  string
+		out time.Duration
+	}{
+		{"0s", 0},
+		{"1s", time.Second},
+		{"0.3s", 300 * time.Millisecond},
+		{"200ms", 200 * time.Millisecond},
+		{" 1.5ms ", 1500 * time.Microsecond},
+		{"-1s", -time.Second},
+		{"", 0},
+		{"s", 0},
+		{"ms", 0},
+		{"1", 0},
+		{"abc", 0},
+		{"1m", 0},
+	}
+
+	for _, c := range cases {
+		if d := cssTime(c.in); d != c.out {
+			t.Errorf("%q: got %v, want %v", c.in, d, c.out)
+		}
+	}
+}
+
+func TestTransitionMax(t *testing.T) {
+	cases := []struct {
+		durations, delays string
+		out               time.Duration
+	}{
+		{"0s", "0s", 0},
+		{"0.3s", "0s", 300 * time.Millisecond},
+		{"0.3s", "200ms", 500 * time.Millisecond},
+		{"0.3s, 1s", "0s", time.Second},
+		{"1s, 0.5s", "0s, 1s", 1500 * time.Millisecond},
+		// delays repeat to cover the longer duration list
+		{"1s, 2s, 3s", "1s, 0s", 4 * time.Second},
+		// durations repeat to cover the longer delay list
+		{"1s", "0s, 2s", 3 * time.Second},
+		{"invalid", "0s", 0},
+		{"1s, invalid", "invalid", time.Second},
+	}
+
+	for _, c := range cases {
+		if d := transitionMax(c.durations, c.delays); d != c.out {
+			t.Errorf("%q / %q: got %v, want %v", c.durations, c.delays, d, c.out)
+		}
+	}
+}
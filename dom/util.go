@@ -1,5 +1,16 @@
 package dom
 
+import (
+	"strconv"
+)
+
+// scroll lock state
+var (
+	scrollLocks    int
+	scrollOverflow string
+	scrollPadding  string
+)
+
 // CaretMove moves caret position inside the current selection.
 func CaretMove(pos int) {
 	sel := window.Call("getSelection")
@@ -13,6 +24,43 @@ func CaretMove(pos int) {
 	rng.Call("setStart", node, pos)
 }
 
+// ScrollLock prevents the document body from scrolling, compensating for the disappearing scrollbar to avoid layout shift.
+// Calls may be nested, for example by stacked modals; the body is only restored by the matching final [ScrollUnlock].
+func ScrollLock() {
+	scrollLocks++
+	if scrollLocks > 1 {
+		return
+	}
+
+	body := doc.Get("body")
+	style := body.Get("style")
+	scrollOverflow = style.Get("overflow").String()
+	scrollPadding = style.Get("paddingRight").String()
+
+	barWidth := window.Get("innerWidth").Float() - doc.Get("documentElement").Get("clientWidth").Float()
+	if barWidth > 0 {
+		computed := window.Call("getComputedStyle", body).Get("paddingRight").String()
+		padding := window.Call("parseFloat", computed).Float()
+		style.Set("paddingRight", strconv.FormatFloat(padding+barWidth, 'f', -1, 64)+"px")
+	}
+	style.Set("overflow", "hidden")
+}
+
+// ScrollUnlock undoes a call to [ScrollLock]. Does nothing if the body is not locked.
+func ScrollUnlock() {
+	if scrollLocks == 0 {
+		return
+	}
+	scrollLocks--
+	if scrollLocks > 0 {
+		return
+	}
+
+	style := doc.Get("body").Get("style")
+	style.Set("overflow", scrollOverflow)
+	style.Set("paddingRight", scrollPadding)
+}
+
 // TextInsert inserts the given string at the current cursor position.
 func TextInsert(str string) {
 	doc.Call("execCommand", "insertText", false, str)
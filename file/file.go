@@ -0,0 +1,163 @@
+// Package file wraps the JS File System Access API, allowing local files to be read and written.
+//
+// The API is currently only available in Chromium based browsers, in secure contexts. The picker functions must be called
+// in response to user activation, such as a click.
+package file
+
+import (
+	"errors"
+	"syscall/js"
+
+	"github.com/blitz-frost/wasm"
+)
+
+var global = js.Global()
+
+var (
+	ErrCanceled   = errors.New("file selection canceled")
+	ErrPermission = errors.New("file access not permitted")
+)
+
+// A Handle represents a local file selected by the user.
+type Handle struct {
+	v js.Value
+}
+
+// Open prompts the user to select one or more files for reading.
+// If types is not empty, selection is restricted to the given file types.
+// Returns [ErrCanceled] if the user dismisses the prompt.
+// Must not be called from the event loop.
+func Open(multiple bool, types ...Type) ([]Handle, error) {
+	opts := map[string]any{
+		"multiple": multiple,
+	}
+	if len(types) > 0 {
+		opts["types"] = typesJs(types)
+	}
+
+	v, err := pick("showOpenFilePicker", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	o := make([]Handle, v.Length())
+	for i := range o {
+		o[i] = Handle{v.Index(i)}
+	}
+	return o, nil
+}
+
+// Save prompts the user to select a file for writing. name is the suggested file name.
+// If types is not empty, selection is restricted to the given file types.
+// Returns [ErrCanceled] if the user dismisses the prompt.
+// Must not be called from the event loop.
+func Save(name string, types ...Type) (Handle, error) {
+	opts := map[string]any{
+		"suggestedName": name,
+	}
+	if len(types) > 0 {
+		opts["types"] = typesJs(types)
+	}
+
+	v, err := pick("showSaveFilePicker", opts)
+	if err != nil {
+		return Handle{}, err
+	}
+	return Handle{v}, nil
+}
+
+func (x Handle) Js() js.Value {
+	return x.v
+}
+
+func (x Handle) Name() string {
+	return x.v.Get("name").String()
+}
+
+// Read returns the full contents of the file.
+// Must not be called from the event loop.
+func (x Handle) Read() (wasm.Bytes, error) {
+	f, err := await(x.v.Call("getFile"))
+	if err != nil {
+		return wasm.Bytes{}, err
+	}
+
+	buf, err := await(f.Call("arrayBuffer"))
+	if err != nil {
+		return wasm.Bytes{}, err
+	}
+
+	return wasm.View(buf), nil
+}
+
+// Write replaces the contents of the file with b.
+// Must not be called from the event loop.
+func (x Handle) Write(b []byte) error {
+	w, err := await(x.v.Call("createWritable"))
+	if err != nil {
+		return err
+	}
+
+	if _, err = await(w.Call("write", wasm.BytesOf(b).Js())); err != nil {
+		w.Call("abort")
+		return err
+	}
+
+	_, err = await(w.Call("close"))
+	return err
+}
+
+// A Type restricts the files that can be selected.
+type Type struct {
+	Description string
+	Accept      map[string][]string // MIME type to file extensions, such as "text/plain": {".txt"}
+}
+
+// await converts the relevant JS errors to package errors.
+func await(promise js.Value) (js.Value, error) {
+	v, err := wasm.Await(promise)
+	return v, convertErr(err)
+}
+
+func convertErr(err error) error {
+	var jsErr *wasm.Error
+	if !errors.As(err, &jsErr) {
+		return err
+	}
+
+	switch jsErr.Name {
+	case "AbortError":
+		return ErrCanceled
+	case "NotAllowedError", "SecurityError":
+		return ErrPermission
+	}
+	return err
+}
+
+func pick(name string, opts map[string]any) (js.Value, error) {
+	promise, err := wasm.Call(global, name, opts)
+	if err != nil {
+		return js.Value{}, convertErr(err)
+	}
+	return await(promise)
+}
+
+func typesJs(types []Type) []any {
+	o := make([]any, len(types))
+	for i, t := range types {
+		accept := make(map[string]any, len(t.Accept))
+		for k, exts := range t.Accept {
+			a := make([]any, len(exts))
+			for j, ext := range exts {
+				a[j] = ext
+			}
+			accept[k] = a
+		}
+
+		o[i] = map[string]any{
+			"description": t.Description,
+			"accept":      accept,
+		}
+	}
+	return o
+}
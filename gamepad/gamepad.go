@@ -0,0 +1,91 @@
+// Package gamepad wraps the JS Gamepad API.
+//
+// Gamepad state is a snapshot; it must be polled, typically once per animation frame.
+// Browsers only expose gamepads after the user has pressed a button on them while the page is visible.
+package gamepad
+
+import (
+	"syscall/js"
+
+	"github.com/blitz-frost/wasm"
+)
+
+var (
+	global    = js.Global()
+	navigator = global.Get("navigator")
+)
+
+// A Gamepad is a snapshot of a gamepad state.
+type Gamepad struct {
+	v js.Value
+}
+
+// All returns the currently connected gamepads.
+func All() []Gamepad {
+	list := navigator.Call("getGamepads")
+
+	var o []Gamepad
+	for i, n := 0, list.Length(); i < n; i++ {
+		// disconnected slots are null
+		if v := list.Index(i); !v.IsNull() {
+			o = append(o, Gamepad{v})
+		}
+	}
+	return o
+}
+
+// OnConnected registers fn to be called when a gamepad becomes available.
+// The returned function deregisters fn and releases the underlying JS function.
+func OnConnected(fn func(Gamepad)) func() {
+	return on("gamepadconnected", fn)
+}
+
+// OnDisconnected registers fn to be called when a gamepad is disconnected.
+// The returned function deregisters fn and releases the underlying JS function.
+func OnDisconnected(fn func(Gamepad)) func() {
+	return on("gamepaddisconnected", fn)
+}
+
+// Axes returns the position of each axis, in the range [-1, 1].
+func (x Gamepad) Axes() []float64 {
+	axes := x.v.Get("axes")
+	o := make([]float64, axes.Length())
+	for i := range o {
+		o[i] = axes.Index(i).Float()
+	}
+	return o
+}
+
+// Buttons returns the value of each button, in the range [0, 1]. Digital buttons are either 0 or 1.
+func (x Gamepad) Buttons() []float64 {
+	buttons := x.v.Get("buttons")
+	o := make([]float64, buttons.Length())
+	for i := range o {
+		o[i] = buttons.Index(i).Get("value").Float()
+	}
+	return o
+}
+
+func (x Gamepad) Connected() bool {
+	return x.v.Get("connected").Bool()
+}
+
+// Id returns the identification string of the device, as reported by the browser.
+func (x Gamepad) Id() string {
+	return x.v.Get("id").String()
+}
+
+// Index returns the position of the gamepad, unique among connected gamepads.
+func (x Gamepad) Index() int {
+	return x.v.Get("index").Int()
+}
+
+func (x Gamepad) Js() js.Value {
+	return x.v
+}
+
+func on(event string, fn func(Gamepad)) func() {
+	return wasm.EventTarget{Value: global}.On(event, func(ev js.Value) {
+		fn(Gamepad{ev.Get("gamepad")})
+	})
+}
@@ -1,6 +1,7 @@
 "use strict";
 
 var goLoadDone;
+var goMemory; // linear memory of the Go module, set by goLoad
 
 // helper for invoking asynhronous Go functions
 function goAsync(fn, ...args) {
@@ -47,6 +48,7 @@ function goLoad() {
 		}).then((buffer) => {
 			return WebAssembly.instantiate(buffer, go.importObject);
 		}).then((res) => {
+			goMemory = res.instance.exports.mem;
 			go.run(res.instance);
 		});
 	});
@@ -3,6 +3,10 @@ package media
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"syscall/js"
 	"time"
@@ -13,7 +17,7 @@ import (
 )
 
 var (
-	media    = js.Global().Get("navigator").Get("mediaDevices")
+	media    = mediaDevices()
 	recorder = js.Global().Get("MediaRecorder")
 	source   = js.Global().Get("MediaSource")
 )
@@ -48,6 +52,62 @@ const (
 	Video      = "video"
 )
 
+type AudioSettings struct {
+	Settings
+}
+
+func MakeAudioSettings() AudioSettings {
+	return AudioSettings{makeSettings()}
+}
+
+func (x AudioSettings) AutoGainControl() (Qualifier, bool) {
+	return x.boolGet("autoGainControl")
+}
+
+func (x AudioSettings) AutoGainControlSet(q Qualifier, v bool) {
+	x.boolSet("autoGainControl", q, v)
+}
+
+func (x AudioSettings) ChannelCount() Uint {
+	return x.uintGet("channelCount")
+}
+
+func (x AudioSettings) ChannelCountSet(u Uint) {
+	x.uintSet("channelCount", u)
+}
+
+func (x AudioSettings) EchoCancellation() (Qualifier, bool) {
+	return x.boolGet("echoCancellation")
+}
+
+func (x AudioSettings) EchoCancellationSet(q Qualifier, v bool) {
+	x.boolSet("echoCancellation", q, v)
+}
+
+func (x AudioSettings) NoiseSuppression() (Qualifier, bool) {
+	return x.boolGet("noiseSuppression")
+}
+
+func (x AudioSettings) NoiseSuppressionSet(q Qualifier, v bool) {
+	x.boolSet("noiseSuppression", q, v)
+}
+
+func (x AudioSettings) SampleRate() Uint {
+	return x.uintGet("sampleRate")
+}
+
+func (x AudioSettings) SampleRateSet(u Uint) {
+	x.uintSet("sampleRate", u)
+}
+
+func (x AudioSettings) SampleSize() Uint {
+	return x.uintGet("sampleSize")
+}
+
+func (x AudioSettings) SampleSizeSet(u Uint) {
+	x.uintSet("sampleSize", u)
+}
+
 type Buffer struct {
 	v js.Value
 
@@ -73,26 +133,77 @@ func (x *Buffer) Write(b []byte) error {
 	return nil
 }
 
+// CameraByFacing returns the first camera facing in the requested direction.
+// Chromium based browsers report the facing mode directly; elsewhere, it is guessed from the device label.
+// Labels are only available after the user has granted camera permission, for example through a prior call to [Get].
+// Returns false if no matching camera is found.
+func CameraByFacing(fm FacingMode) (Device, bool) {
+	all, err := devices(VideoInput)
+	if err != nil {
+		return Device{}, false
+	}
+
+	for _, v := range all {
+		if !v.Get("getCapabilities").IsUndefined() {
+			modes := v.Call("getCapabilities").Get("facingMode")
+			if !modes.IsUndefined() && modes.Length() > 0 {
+				for i, n := 0, modes.Length(); i < n; i++ {
+					if modes.Index(i).String() == string(fm) {
+						return deviceOf(v), true
+					}
+				}
+				continue
+			}
+		}
+
+		if labelFacing(v.Get("label").String()) == fm {
+			return deviceOf(v), true
+		}
+	}
+
+	return Device{}, false
+}
+
 type Device struct {
 	Id      string
 	GroupId string
+	Label   string // empty unless the user has granted permission for this kind of device
+}
+
+func deviceOf(v js.Value) Device {
+	return Device{
+		Id:      v.Get("deviceId").String(),
+		GroupId: v.Get("groupId").String(),
+		Label:   v.Get("label").String(),
+	}
 }
 
 // Devices returns a slice of all available devices of the specified kind.
 func Devices(kind DeviceKind) ([]Device, error) {
-	allJs, err := wasm.Await(media.Call("enumerateDevices"))
+	all, err := devices(kind)
 	if err != nil {
 		return nil, err
 	}
 
-	var o []Device
+	o := make([]Device, len(all))
+	for i, v := range all {
+		o[i] = deviceOf(v)
+	}
+
+	return o, nil
+}
+
+func devices(kind DeviceKind) ([]js.Value, error) {
+	allJs, err := wasm.CallAwait(media, "enumerateDevices")
+	if err != nil {
+		return nil, err
+	}
+
+	var o []js.Value
 	for i, n := 0, allJs.Length(); i < n; i++ {
 		deviceJs := allJs.Index(i)
 		if deviceJs.Get("kind").String() == string(kind) {
-			o = append(o, Device{
-				Id:      deviceJs.Get("deviceId").String(),
-				GroupId: deviceJs.Get("groupId").String(),
-			})
+			o = append(o, deviceJs)
 		}
 	}
 
@@ -101,10 +212,63 @@ func Devices(kind DeviceKind) ([]Device, error) {
 
 type DeviceKind string
 
+// bitrate estimation
+const bitrateWindow = 5 * time.Second
+
+type chunk struct {
+	t time.Time
+	n int
+}
+
 type FacingMode string
 
 type Float map[Qualifier]float64
 
+// A FrameTransform intercepts the encoded frames of a WebRTC sender or receiver.
+//
+// It relies on the createEncodedStreams method, currently only available in Chromium based browsers, and only for
+// RTCPeerConnection objects created with the encodedInsertableStreams option set to true. Browsers that only implement
+// RTCRtpScriptTransform, which runs in a worker, are not supported.
+type FrameTransform struct {
+	f js.Func
+}
+
+// NewFrameTransform pipes every encoded frame of sender through fn, and forwards the returned data in its place.
+// sender may be an RTCRtpSender or RTCRtpReceiver.
+// fn runs on the event loop and must not block. It may modify and return its input.
+func NewFrameTransform(sender js.Value, fn func(wasm.Bytes) wasm.Bytes) (*FrameTransform, error) {
+	streams, err := wasm.Call(sender, "createEncodedStreams")
+	if err != nil {
+		return nil, err
+	}
+
+	x := FrameTransform{}
+	x.f = wasm.FuncOf(func(this js.Value, args []js.Value) any {
+		frame, controller := args[0], args[1]
+
+		b := fn(wasm.View(frame.Get("data")))
+		// slice yields a new array backed by a buffer of exactly the right size
+		frame.Set("data", b.Js().Call("slice").Get("buffer"))
+
+		controller.Call("enqueue", frame)
+		return nil
+	})
+
+	stream, err := wasm.New(js.Global().Get("TransformStream"), map[string]any{"transform": x.f})
+	if err != nil {
+		x.f.Release()
+		return nil, err
+	}
+	streams.Get("readable").Call("pipeThrough", stream).Call("pipeTo", streams.Get("writable"))
+
+	return &x, nil
+}
+
+// Release frees the underlying JS function. Must only be called after the associated connection has been closed.
+func (x *FrameTransform) Release() {
+	x.f.Release()
+}
+
 type Kind string
 
 type Qualifier string
@@ -125,6 +289,12 @@ type Recorder struct {
 	stop   chan struct{}
 
 	mux sync.Mutex
+
+	// recorded data statistics
+	written  int64
+	chunks   []chunk   // within the last bitrateWindow; always includes the latest one
+	base     time.Time // time the data in chunks starts from: recording start, or the last trimmed chunk
+	statsMux sync.Mutex
 }
 
 func NewRecorder(s Stream, t Type, audioBitRate, videoBitRate float64) *Recorder {
@@ -154,15 +324,20 @@ func NewRecorder(s Stream, t Type, audioBitRate, videoBitRate float64) *Recorder
 		stop:    make(chan struct{}),
 	}
 
-	x.onErrorJs = js.FuncOf(func(this js.Value, args []js.Value) any {
-		errJs := args[0].Get("error")
-		msg := errJs.Get("message").String()
-		err := errors.New(msg)
-		x.onError(err)
+	x.onErrorJs = wasm.FuncOf(func(this js.Value, args []js.Value) any {
+		// depending on the browser, the event may be a plain Event or ErrorEvent, without an error property
+		ev := args[0]
+		msg := "recorder error"
+		if errJs := ev.Get("error"); errJs.Type() == js.TypeObject {
+			msg = wasm.StringOr(errJs.Get("message"), msg)
+		} else {
+			msg = wasm.StringOr(ev.Get("message"), msg)
+		}
+		x.onError(errors.New(msg))
 
 		return nil
 	})
-	x.onArray = js.FuncOf(func(this js.Value, args []js.Value) any {
+	x.onArray = wasm.FuncOf(func(this js.Value, args []js.Value) any {
 		buf := wasm.View(args[0])
 
 		n := buf.Len()
@@ -170,6 +345,8 @@ func NewRecorder(s Stream, t Type, audioBitRate, videoBitRate float64) *Recorder
 		if n == 0 {
 			return nil
 		}
+		x.record(n)
+
 		if len(x.buf) < n {
 			x.buf = make([]byte, n)
 		}
@@ -182,7 +359,7 @@ func NewRecorder(s Stream, t Type, audioBitRate, videoBitRate float64) *Recorder
 
 		return nil
 	})
-	x.onData = js.FuncOf(func(this js.Value, args []js.Value) any {
+	x.onData = wasm.FuncOf(func(this js.Value, args []js.Value) any {
 		data := args[0].Get("data")
 		arrayPromise := data.Call("arrayBuffer")
 		arrayPromise.Call("then", x.onArray)
@@ -191,16 +368,55 @@ func NewRecorder(s Stream, t Type, audioBitRate, videoBitRate float64) *Recorder
 	})
 
 	v.Set("ondataavailable", x.onData)
+	v.Set("onerror", x.onErrorJs)
 
 	return &x
 }
 
+// BytesWritten returns the total number of recorded bytes delivered so far.
+func (x *Recorder) BytesWritten() int64 {
+	x.statsMux.Lock()
+	defer x.statsMux.Unlock()
+
+	return x.written
+}
+
+// EstimatedBitrate returns the measured output bitrate in bits per second, averaged over the chunks delivered in the last few
+// seconds. If chunks are delivered less often, the average covers at least the latest chunk.
+// Returns 0 until the first chunk is delivered. Paused intervals count towards the average.
+func (x *Recorder) EstimatedBitrate() float64 {
+	x.statsMux.Lock()
+	defer x.statsMux.Unlock()
+
+	if len(x.chunks) == 0 {
+		return 0
+	}
+	x.trim(time.Now())
+
+	// each chunk holds the data recorded since the previous one
+	span := x.chunks[len(x.chunks)-1].t.Sub(x.base)
+	if span <= 0 {
+		return 0
+	}
+
+	var n int
+	for _, c := range x.chunks {
+		n += c.n
+	}
+	return float64(n) * 8 / span.Seconds()
+}
+
 func (x *Recorder) ReaderChain(dst msg.ReaderTaker) error {
 	x.dst = dst
 	return nil
 }
 
+// OnError sets the function to be called when recording fails, or when the chained destination returns an error.
+// A nil fn discards errors.
 func (x *Recorder) OnError(fn func(error)) {
+	if fn == nil {
+		fn = func(error) {}
+	}
 	x.onError = fn
 }
 
@@ -218,6 +434,9 @@ func (x *Recorder) Pause() {
 }
 
 func (x *Recorder) Release() {
+	x.v.Set("ondataavailable", js.Null())
+	x.v.Set("onerror", js.Null())
+
 	x.onArray.Release()
 	x.onData.Release()
 	x.onErrorJs.Release()
@@ -249,6 +468,12 @@ func (x *Recorder) Start(d time.Duration) {
 
 	x.v.Call("start")
 
+	x.statsMux.Lock()
+	if x.base.IsZero() {
+		x.base = time.Now()
+	}
+	x.statsMux.Unlock()
+
 	go x.listen(d)
 }
 
@@ -265,6 +490,29 @@ func (x *Recorder) Stop() {
 	x.v.Call("stop")
 }
 
+// record updates statistics with a newly delivered chunk of n bytes.
+func (x *Recorder) record(n int) {
+	x.statsMux.Lock()
+	defer x.statsMux.Unlock()
+
+	now := time.Now()
+	x.written += int64(n)
+	x.chunks = append(x.chunks, chunk{now, n})
+	x.trim(now)
+}
+
+// trim drops chunks older than bitrateWindow, except the latest one, so that the estimate covers at least one chunk interval.
+func (x *Recorder) trim(now time.Time) {
+	i := 0
+	for i+1 < len(x.chunks) && now.Sub(x.chunks[i].t) > bitrateWindow {
+		i++
+	}
+	if i > 0 {
+		x.base = x.chunks[i-1].t
+	}
+	x.chunks = x.chunks[i:]
+}
+
 func (x *Recorder) listen(d time.Duration) {
 	t := time.NewTicker(d)
 	for {
@@ -280,6 +528,79 @@ func (x *Recorder) listen(d time.Duration) {
 
 type ResizeMode string
 
+// A Scaler redraws a video stream onto a canvas of a different resolution, producing a new stream.
+// Every frame is drawn on the main thread, so the CPU cost grows with the target resolution and frame rate.
+// Drawing is tied to animation frames, which browsers throttle or pause in background tabs.
+type Scaler struct {
+	video  js.Value
+	canvas js.Value
+	ctx    js.Value
+	out    Stream
+
+	draw js.Func
+	id   js.Value
+	done bool
+}
+
+// NewScaler starts scaling the video of s to the given size, capturing at most fps frames per second.
+// The audio tracks of s are not carried over.
+func NewScaler(s Stream, width, height uint, fps float64) (*Scaler, error) {
+	doc := js.Global().Get("document")
+
+	x := &Scaler{
+		video:  doc.Call("createElement", "video"),
+		canvas: doc.Call("createElement", "canvas"),
+	}
+	x.video.Set("muted", true)
+	x.video.Set("srcObject", s.v)
+	x.canvas.Set("width", width)
+	x.canvas.Set("height", height)
+	x.ctx = x.canvas.Call("getContext", "2d")
+
+	out, err := wasm.Call(x.canvas, "captureStream", fps)
+	if err != nil {
+		return nil, err
+	}
+	x.out = Stream{out}
+
+	x.draw = js.FuncOf(func(this js.Value, args []js.Value) any {
+		if x.done {
+			return nil
+		}
+		x.ctx.Call("drawImage", x.video, 0, 0, width, height)
+		x.id = js.Global().Call("requestAnimationFrame", x.draw)
+		return nil
+	})
+
+	// autoplay is not guaranteed; playing a muted video is always allowed
+	x.video.Call("play")
+	x.id = js.Global().Call("requestAnimationFrame", x.draw)
+
+	return x, nil
+}
+
+// Release stops scaling, ends the output stream and frees the underlying JS resources.
+func (x *Scaler) Release() {
+	if x.done {
+		return
+	}
+	x.done = true
+
+	js.Global().Call("cancelAnimationFrame", x.id)
+	x.draw.Release()
+
+	tracks := x.out.v.Call("getTracks")
+	for i, n := 0, tracks.Length(); i < n; i++ {
+		tracks.Index(i).Call("stop")
+	}
+	x.video.Set("srcObject", js.Null())
+}
+
+// Stream returns the scaled stream, suitable for a new [Recorder].
+func (x *Scaler) Stream() Stream {
+	return x.out
+}
+
 // Settings defines a set of properties common to all stream types.
 type Settings struct {
 	v js.Value
@@ -290,6 +611,15 @@ func makeSettings() Settings {
 	return Settings{v}
 }
 
+// mediaDevices returns navigator.mediaDevices, or undefined outside of browsers, such as when testing under node.
+func mediaDevices() js.Value {
+	nav := js.Global().Get("navigator")
+	if nav.IsUndefined() {
+		return nav
+	}
+	return nav.Get("mediaDevices")
+}
+
 func (x Settings) Device() (Qualifier, string) {
 	return x.stringGet("deviceId")
 }
@@ -306,6 +636,39 @@ func (x Settings) GroupSet(q Qualifier, id string) {
 	x.stringSet("groupId", q, id)
 }
 
+// MarshalJSON encodes the underlying constraints object, for example to persist a user's choice.
+// Zero value settings are encoded as null.
+func (x Settings) MarshalJSON() ([]byte, error) {
+	if x.v.IsUndefined() {
+		return []byte("null"), nil
+	}
+
+	s, err := wasm.JsonStringify(x.v)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalJSON replaces the underlying constraints object with the decoded one.
+// null results in zero value settings.
+func (x *Settings) UnmarshalJSON(b []byte) error {
+	v, err := wasm.JsonParse(string(b))
+	if err != nil {
+		return err
+	}
+
+	if v.IsNull() {
+		x.v = js.Value{}
+		return nil
+	}
+	if v.Type() != js.TypeObject {
+		return errors.New("settings must be a JSON object")
+	}
+	x.v = v
+	return nil
+}
+
 func (x Settings) boolGet(name string) (Qualifier, bool) {
 	oJs := x.v.Get(name)
 	switch oJs.Type() {
@@ -359,28 +722,43 @@ func (x Settings) uintSet(name string, v Uint) {
 type Source struct {
 	v js.Value
 
-	onClose js.Func
-	onEnd   js.Func
-	onOpen  js.Func
+	buffers []js.Value
+
+	onClose   js.Func
+	onEnd     js.Func
+	onErrorJs js.Func // shared by all buffers
+	onOpen    js.Func
+
+	onError func(error)
 }
 
 func NewSource() *Source {
 	v := source.New()
 
-	return &Source{
-		v: v,
+	x := &Source{
+		v:       v,
+		onError: func(error) {},
 	}
+	x.onErrorJs = wasm.FuncOf(func(this js.Value, args []js.Value) any {
+		// SourceBuffer error events carry no details
+		x.onError(errors.New("source buffer error"))
+		return nil
+	})
+
+	return x
 }
 
 func (x *Source) NewBuffer(t Type) *Buffer {
 	s := typeString(t)
 	v := x.v.Call("addSourceBuffer", s)
+	v.Set("onerror", x.onErrorJs)
+	x.buffers = append(x.buffers, v)
 	return newBuffer(v)
 }
 
 func (x *Source) OnClose(fn func()) {
 	x.onClose.Release()
-	x.onClose = js.FuncOf(func(this js.Value, args []js.Value) any {
+	x.onClose = wasm.FuncOf(func(this js.Value, args []js.Value) any {
 		fn()
 		return nil
 	})
@@ -389,16 +767,25 @@ func (x *Source) OnClose(fn func()) {
 
 func (x *Source) OnEnd(fn func()) {
 	x.onEnd.Release()
-	x.onEnd = js.FuncOf(func(this js.Value, args []js.Value) any {
+	x.onEnd = wasm.FuncOf(func(this js.Value, args []js.Value) any {
 		fn()
 		return nil
 	})
 	x.v.Set("onsourceended", x.onEnd)
 }
 
+// OnError sets the function to be called when appending data to any of the Source's buffers fails.
+// A nil fn discards errors.
+func (x *Source) OnError(fn func(error)) {
+	if fn == nil {
+		fn = func(error) {}
+	}
+	x.onError = fn
+}
+
 func (x *Source) OnOpen(fn func()) {
 	x.onOpen.Release()
-	x.onOpen = js.FuncOf(func(this js.Value, args []js.Value) any {
+	x.onOpen = wasm.FuncOf(func(this js.Value, args []js.Value) any {
 		fn()
 		return nil
 	})
@@ -406,8 +793,14 @@ func (x *Source) OnOpen(fn func()) {
 }
 
 func (x *Source) Release() {
+	for _, v := range x.buffers {
+		v.Set("onerror", js.Null())
+	}
+	x.buffers = nil
+
 	x.onClose.Release()
 	x.onEnd.Release()
+	x.onErrorJs.Release()
 	x.onOpen.Release()
 }
 
@@ -428,6 +821,78 @@ func (x Stream) Js() js.Value {
 	return x.v
 }
 
+// SwitchCamera stops the video of x and returns a new video stream from the camera facing the opposite direction.
+// If the current direction is unknown, the user facing camera is assumed to be active.
+// Other tracks of x, such as audio, are left untouched.
+//
+// The new camera is opened while the current one is still running. Some devices can't open two cameras at the same time, so
+// if that fails with a NotReadableError (device busy), the video of x is stopped and opening is retried. Other errors are
+// returned immediately, with x untouched.
+// On error, the returned stream holds the original camera: x unchanged if its video was never stopped, otherwise a new stream
+// reopened from the same device. Only if reopening fails as well is x returned with its video stopped.
+// Must not be called from the event loop.
+func (x Stream) SwitchCamera() (Stream, error) {
+	cameras, err := Devices(VideoInput)
+	if err != nil {
+		return x, err
+	}
+	if len(cameras) < 2 {
+		return x, errors.New("no other camera available")
+	}
+
+	tracks := x.VideoTracks()
+
+	current := User
+	if len(tracks) > 0 {
+		if _, fm := tracks[0].Settings().FacingMode(); fm != "" {
+			current = fm
+		}
+	}
+
+	var target FacingMode = User
+	if current == User {
+		target = Environment
+	}
+
+	vs := MakeVideoSettings()
+	if d, ok := CameraByFacing(target); ok {
+		vs.DeviceSet(Exact, d.Id)
+	} else {
+		// no labels or capabilities to go by, so let the browser decide
+		vs.FacingModeSet(Exact, target)
+	}
+
+	o, err := Get(vs)
+	if err == nil {
+		stopAll(tracks)
+		return o, nil
+	}
+
+	// some devices can't open two cameras at the same time, reporting the new one as busy
+	// other errors, such as denied permission or a missing camera, would only repeat
+	var jsErr *wasm.Error
+	if !errors.As(err, &jsErr) || jsErr.Name != "NotReadableError" {
+		return x, fmt.Errorf("no %s facing camera: %w", target, err)
+	}
+
+	stopAll(tracks)
+	if o, err = Get(vs); err == nil {
+		return o, nil
+	}
+	err = fmt.Errorf("no %s facing camera: %w", target, err)
+
+	if len(tracks) == 0 {
+		return x, err
+	}
+	restore := MakeVideoSettings()
+	_, id := tracks[0].Settings().Device()
+	restore.DeviceSet(Exact, id)
+	if o, rErr := Get(restore); rErr == nil {
+		return o, err
+	}
+	return x, err
+}
+
 func (x Stream) VideoTracks() []VideoTrack {
 	oJs := x.v.Call("getVideoTracks")
 	o := make([]VideoTrack, oJs.Length())
@@ -477,6 +942,49 @@ func (x VideoSettings) AspectRatioSet(f Float) {
 	x.floatSet("aspectRatio", f)
 }
 
+// Diff compares x (typically the requested constraints) to other (typically the actual settings).
+// For each property that x constrains and other doesn't satisfy, the returned map holds the pair {x value, other value},
+// formatted as text. Exact or ideal values must match, min and max values must bound the other value; qualifiers themselves
+// are not compared, so a met ideal constraint is not a difference. Properties that x doesn't constrain are skipped.
+// Useful to debug failing or partially applied constraints.
+func (x VideoSettings) Diff(other VideoSettings) map[string][2]string {
+	o := make(map[string][2]string)
+	add := func(name string, met bool, a, b string) {
+		if !met {
+			o[name] = [2]string{a, b}
+		}
+	}
+
+	ar, arOther := x.AspectRatio(), other.AspectRatio()
+	add("aspectRatio", numberMet(ar, arOther), fmtFloat(ar), fmtFloat(arOther))
+
+	q, id := x.Device()
+	qOther, idOther := other.Device()
+	add("deviceId", singleMet(q, id, idOther), fmtSingle(q, id), fmtSingle(qOther, idOther))
+
+	q, fm := x.FacingMode()
+	qOther, fmOther := other.FacingMode()
+	add("facingMode", singleMet(q, fm, fmOther), fmtSingle(q, string(fm)), fmtSingle(qOther, string(fmOther)))
+
+	fr, frOther := x.FrameRate(), other.FrameRate()
+	add("frameRate", numberMet(fr, frOther), fmtFloat(fr), fmtFloat(frOther))
+
+	q, group := x.Group()
+	qOther, groupOther := other.Group()
+	add("groupId", singleMet(q, group, groupOther), fmtSingle(q, group), fmtSingle(qOther, groupOther))
+
+	h, hOther := x.Height(), other.Height()
+	add("height", numberMet(h, hOther), fmtUint(h), fmtUint(hOther))
+
+	rm, rmOther := x.resizeModeString(), other.resizeModeString()
+	add("resizeMode", rm == "" || rm == rmOther, rm, rmOther)
+
+	w, wOther := x.Width(), other.Width()
+	add("width", numberMet(w, wOther), fmtUint(w), fmtUint(wOther))
+
+	return o
+}
+
 func (x VideoSettings) FacingMode() (Qualifier, FacingMode) {
 	q, o := x.stringGet("facingMode")
 	return q, FacingMode(o)
@@ -509,6 +1017,14 @@ func (x VideoSettings) ResizeMode() ResizeMode {
 	return ResizeMode(s)
 }
 
+func (x VideoSettings) resizeModeString() string {
+	v := x.v.Get("resizeMode")
+	if v.Type() != js.TypeString {
+		return ""
+	}
+	return v.String()
+}
+
 func (x VideoSettings) ResizeModeSet(rm ResizeMode) {
 	x.v.Set("resizeMode", string(rm))
 }
@@ -524,7 +1040,7 @@ func (x VideoSettings) WidthSet(u Uint) {
 type VideoTrack Track
 
 func (x VideoTrack) Apply(vs VideoSettings) error {
-	_, err := wasm.Await(x.v.Call("applyConstraints", vs.v))
+	_, err := wasm.CallAwait(x.v, "applyConstraints", vs.v)
 	return err
 }
 
@@ -549,17 +1065,202 @@ type single interface {
 // If a setting is a zero value, it will be ignored. Unmodified settings obtained from a respective make function is equivalent to requesting any stream of that kind.
 func Get(video VideoSettings) (Stream, error) {
 	con := make(map[string]any)
-	if !video.v.IsUndefined() {
-		k := wasm.Keys(video.v)
-		if len(k) == 0 {
-			con["video"] = true
-		} else {
-			con["video"] = video.v
+	constraintSet(con, "video", video.Settings)
+
+	val, err := wasm.CallAwait(media, "getUserMedia", con)
+	return Stream{val}, err
+}
+
+// GetBoth is like [Get], but requests video and audio together, resulting in a single permission prompt and a single stream.
+func GetBoth(video VideoSettings, audio AudioSettings) (Stream, error) {
+	con := make(map[string]any)
+	constraintSet(con, "video", video.Settings)
+	constraintSet(con, "audio", audio.Settings)
+
+	val, err := wasm.CallAwait(media, "getUserMedia", con)
+	return Stream{val}, err
+}
+
+// GetRelaxed is like [Get], but on failure due to unsatisfiable constraints, it progressively relaxes them and tries again.
+// The offending constraint is first downgraded to an ideal value, then dropped altogether if still unsatisfiable.
+// Returns a description of each relaxation performed, such as "width: strict -> ideal" or "frameRate: removed".
+// video itself is not modified.
+func GetRelaxed(video VideoSettings) (Stream, []string, error) {
+	if video.v.IsUndefined() {
+		o, err := Get(video)
+		return o, nil, err
+	}
+
+	v, err := wasm.StructuredClone(video.v)
+	if err != nil {
+		return Stream{}, nil, err
+	}
+	vs := VideoSettings{Settings{v}}
+
+	var report []string
+	for {
+		o, err := Get(vs)
+		if err == nil {
+			return o, report, nil
+		}
+
+		var jsErr *wasm.Error
+		if !errors.As(err, &jsErr) || jsErr.Name != "OverconstrainedError" {
+			return Stream{}, report, err
+		}
+
+		name := wasm.StringOr(jsErr.Value.Get("constraint"), "")
+		r, ok := relax(v, name)
+		if !ok {
+			return Stream{}, report, err
 		}
+		report = append(report, r)
 	}
+}
 
-	val, err := wasm.Await(media.Call("getUserMedia", con))
-	return Stream{val}, err
+// relax loosens the named constraint of a constraints object, or the first strict one if name is empty.
+// Returns false if there is nothing left to relax.
+func relax(con js.Value, name string) (string, bool) {
+	if name == "" {
+		for _, k := range wasm.Keys(con) {
+			if strict(con.Get(k)) {
+				name = k
+				break
+			}
+		}
+		if name == "" {
+			return "", false
+		}
+	}
+
+	val := con.Get(name)
+	if val.IsUndefined() {
+		return "", false
+	}
+
+	if strict(val) {
+		ideal := val.Get(string(Ideal))
+		for _, q := range []Qualifier{Exact, Min, Max} {
+			if !ideal.IsUndefined() {
+				break
+			}
+			ideal = val.Get(string(q))
+		}
+		con.Set(name, map[string]any{string(Ideal): ideal})
+		return name + ": strict -> ideal", true
+	}
+
+	con.Delete(name)
+	return name + ": removed", true
+}
+
+// strict returns true if a constraint value has an exact, min or max qualifier.
+func strict(v js.Value) bool {
+	if v.Type() != js.TypeObject {
+		return false
+	}
+	for _, q := range []Qualifier{Exact, Min, Max} {
+		if !v.Get(string(q)).IsUndefined() {
+			return true
+		}
+	}
+	return false
+}
+
+// constraintSet adds s to a getUserMedia constraints object, under the given kind.
+// Zero value settings are ignored, while empty settings request any stream of that kind.
+func constraintSet(con map[string]any, kind string, s Settings) {
+	if s.v.IsUndefined() {
+		return
+	}
+
+	if len(wasm.Keys(s.v)) == 0 {
+		con[kind] = true
+	} else {
+		con[kind] = s.v
+	}
+}
+
+// fmtFloat formats a Float as space separated "qualifier:value" pairs, sorted by qualifier.
+func fmtFloat(v Float) string {
+	return fmtNumber(v, func(a float64) string {
+		return strconv.FormatFloat(a, 'g', -1, 64)
+	})
+}
+
+func fmtNumber[T number](v map[Qualifier]T, format func(T) string) string {
+	keys := make([]string, 0, len(v))
+	for q := range v {
+		keys = append(keys, string(q))
+	}
+	sort.Strings(keys)
+
+	var o string
+	for i, k := range keys {
+		if i > 0 {
+			o += " "
+		}
+		o += k + ":" + format(v[Qualifier(k)])
+	}
+	return o
+}
+
+func fmtSingle(q Qualifier, v string) string {
+	if q == "" {
+		return ""
+	}
+	return string(q) + ":" + v
+}
+
+func fmtUint(v Uint) string {
+	return fmtNumber(v, func(a uint64) string {
+		return strconv.FormatUint(a, 10)
+	})
+}
+
+// labelFacing guesses the facing mode of a camera from its label.
+// Returns an empty string if the label is inconclusive.
+func labelFacing(label string) FacingMode {
+	label = strings.ToLower(label)
+	switch {
+	case strings.Contains(label, "back"), strings.Contains(label, "rear"), strings.Contains(label, "environment"):
+		return Environment
+	case strings.Contains(label, "front"), strings.Contains(label, "user"):
+		return User
+	}
+	return ""
+}
+
+// numberMet returns true if the value of actual satisfies the requested constraint.
+// The value of actual is its exact or ideal value; plain values, as returned by getSettings, count as exact.
+func numberMet[T number](requested, actual map[Qualifier]T) bool {
+	if len(requested) == 0 {
+		return true
+	}
+
+	v, ok := numberTarget(actual)
+	if !ok {
+		return false
+	}
+	if t, ok := numberTarget(requested); ok {
+		return t == v
+	}
+	if m, ok := requested[Min]; ok && v < m {
+		return false
+	}
+	if m, ok := requested[Max]; ok && v > m {
+		return false
+	}
+	return true
+}
+
+// numberTarget returns the exact value of v, or else its ideal value.
+func numberTarget[T number](v map[Qualifier]T) (T, bool) {
+	if o, ok := v[Exact]; ok {
+		return o, true
+	}
+	o, ok := v[Ideal]
+	return o, ok
 }
 
 func numberGet[T number](x js.Value, name string) map[Qualifier]T {
@@ -588,6 +1289,11 @@ func numberSet[M ~map[Qualifier]T, T number](x js.Value, name string, v M) {
 	x.Set(name, m)
 }
 
+// singleMet returns true if the actual value satisfies a requested single value constraint, with qualifier q.
+func singleMet[T comparable](q Qualifier, requested, actual T) bool {
+	return q == "" || requested == actual
+}
+
 func singleSet[T single](x js.Value, name string, q Qualifier, v T) {
 	m := map[string]any{
 		string(q): v,
@@ -596,6 +1302,12 @@ func singleSet[T single](x js.Value, name string, q Qualifier, v T) {
 	x.Set(name, m)
 }
 
+func stopAll(tracks []VideoTrack) {
+	for _, t := range tracks {
+		t.v.Call("stop")
+	}
+}
+
 func typeString(t Type) string {
 	o := string(t.Kind())
 	o += "/" + t.Format()
@@ -0,0 +1,77 @@
+package media
+
+import "testing"
+
+// actualSettings builds settings holding plain values, as returned by getSettings.
+func actualSettings(values map[string]any) VideoSettings {
+	x := MakeVideoSettings()
+	for k, v := range values {
+		x.v.Set(k, v)
+	}
+	return x
+}
+
+func TestVideoSettingsDiff(t *testing.T) {
+	actual := actualSettings(map[string]any{
+		"deviceId":   "cam0",
+		"facingMode": "user",
+		"frameRate":  30,
+		"height":     720,
+		"width":      1280,
+	})
+
+	cases := []struct {
+		name      string
+		requested func(VideoSettings)
+		diff      map[string][2]string
+	}{
+		{"unconstrained", func(VideoSettings) {}, map[string][2]string{}},
+		{"ideal met", func(x VideoSettings) {
+			x.WidthSet(Uint{Ideal: 1280})
+		}, map[string][2]string{}},
+		{"exact met", func(x VideoSettings) {
+			x.HeightSet(Uint{Exact: 720})
+			x.DeviceSet(Exact, "cam0")
+			x.FacingModeSet(Ideal, User)
+		}, map[string][2]string{}},
+		{"range met", func(x VideoSettings) {
+			x.FrameRateSet(Float{Min: 24, Max: 60})
+		}, map[string][2]string{}},
+		{"ideal missed", func(x VideoSettings) {
+			x.WidthSet(Uint{Ideal: 1920})
+		}, map[string][2]string{
+			"width": {"ideal:1920", "exact:1280"},
+		}},
+		{"range missed", func(x VideoSettings) {
+			x.FrameRateSet(Float{Min: 60})
+		}, map[string][2]string{
+			"frameRate": {"min:60", "exact:30"},
+		}},
+		{"single missed", func(x VideoSettings) {
+			x.FacingModeSet(Exact, Environment)
+		}, map[string][2]string{
+			"facingMode": {"exact:environment", "exact:user"},
+		}},
+		{"unavailable", func(x VideoSettings) {
+			x.AspectRatioSet(Float{Ideal: 1.5})
+		}, map[string][2]string{
+			"aspectRatio": {"ideal:1.5", ""},
+		}},
+	}
+
+	for _, c := range cases {
+		requested := MakeVideoSettings()
+		c.requested(requested)
+
+		diff := requested.Diff(actual)
+		if len(diff) != len(c.diff) {
+			t.Errorf("%s: got %v, want %v", c.name, diff, c.diff)
+			continue
+		}
+		for k, v := range c.diff {
+			if diff[k] != v {
+				t.Errorf("%s: %s: got %v, want %v", c.name, k, diff[k], v)
+			}
+		}
+	}
+}
@@ -1,7 +1,13 @@
 package wasm
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"unsafe"
 
 	"syscall/js"
 
@@ -11,14 +17,99 @@ import (
 var (
 	global = js.Global()
 
+	abort       = global.Get("AbortController")
 	array       = global.Get("Uint8Array")
 	console     = global.Get("console")
 	catchCall   = global.Get("goCatchCall")
 	catchInvoke = global.Get("goCatchInvoke")
 	catchNew    = global.Get("goCatchNew")
+	jsErrorType = global.Get("Error")
+	json        = global.Get("JSON")
 	object      = global.Get("Object")
+	performance = global.Get("performance")
+	promiseType = global.Get("Promise")
+	stringType  = global.Get("String")
+	clone       = global.Get("structuredClone")
 )
 
+// ErrEventLoop is returned by blocking functions that are called from the event loop, where they would otherwise deadlock.
+var ErrEventLoop = errors.New("blocking call on event loop")
+
+// number of FuncOf functions currently executing
+var loopDepth int
+
+// global error handlers
+var (
+	onError     js.Func
+	onRejection js.Func
+)
+
+// An AbortController wraps a JS AbortController, used to cancel operations that accept an AbortSignal, such as [Fetch] or
+// event listeners.
+type AbortController struct {
+	v js.Value
+}
+
+func AbortControllerMake() AbortController {
+	return AbortController{abort.New()}
+}
+
+// Abort cancels all operations using the signal of x.
+func (x AbortController) Abort() {
+	x.v.Call("abort")
+}
+
+// AbortReason is like Abort, but with a custom reason, such as an error returned by [JsError].
+func (x AbortController) AbortReason(reason any) {
+	x.v.Call("abort", reason)
+}
+
+// Aborted returns true if x has been aborted.
+func (x AbortController) Aborted() bool {
+	return x.Signal().Get("aborted").Bool()
+}
+
+func (x AbortController) Js() js.Value {
+	return x.v
+}
+
+// Signal returns the AbortSignal of x, to be passed to cancellable operations, for example as the "signal" fetch option.
+func (x AbortController) Signal() js.Value {
+	return x.v.Get("signal")
+}
+
+// Args builds argument lists of dynamic length for [Call], [Invoke] and [New].
+// Each value is converted through [ValueOf] when added; the first conversion failure is retained and reported by [Args.Values].
+// The zero value is ready for use.
+type Args struct {
+	vals []any
+	err  error
+}
+
+// Add appends the given values to x and returns x, for chaining.
+func (x *Args) Add(v ...any) *Args {
+	for _, a := range v {
+		val, err := ValueOf(a)
+		if err != nil {
+			if x.err == nil {
+				x.err = fmt.Errorf("argument %d: %w", len(x.vals), err)
+			}
+			val = js.Undefined()
+		}
+		x.vals = append(x.vals, val)
+	}
+	return x
+}
+
+func (x *Args) Len() int {
+	return len(x.vals)
+}
+
+// Values returns the converted arguments, ready to be expanded into a variadic call.
+func (x *Args) Values() ([]any, error) {
+	return x.vals, x.err
+}
+
 // Bytes mimics []byte using a JS Uint8Array as the underlying array.
 type Bytes struct {
 	v        js.Value
@@ -32,6 +123,16 @@ func BytesOf(b []byte) Bytes {
 	return x
 }
 
+// BytesOfString is like BytesOf, but avoids the intermediate []byte conversion.
+func BytesOfString(s string) Bytes {
+	x := BytesMake(len(s), len(s))
+	if len(s) > 0 {
+		// read only access to the string data
+		js.CopyBytesToJS(x.v, unsafe.Slice(unsafe.StringData(s), len(s)))
+	}
+	return x
+}
+
 func BytesMake(length, capacity int) Bytes {
 	v := array.New(capacity)
 	return Bytes{v, length, capacity}
@@ -67,6 +168,27 @@ func (x Bytes) Cap() int {
 	return x.capacity
 }
 
+// Compare returns an integer comparing x and y lexicographically: 0 if x == y, -1 if x < y, and +1 if x > y.
+// JS memory can't be compared directly from Go, so both contents are copied into pooled Go buffers. The cost is linear in their
+// combined length, but doesn't allocate in the steady state.
+func (x Bytes) Compare(y Bytes) int {
+	a := bytePool.get(x.length)
+	b := bytePool.get(y.length)
+	// zero values have no underlying array
+	if x.length > 0 {
+		x.CopyTo(*a)
+	}
+	if y.length > 0 {
+		y.CopyTo(*b)
+	}
+
+	o := bytes.Compare(*a, *b)
+
+	bytePool.put(a)
+	bytePool.put(b)
+	return o
+}
+
 func (x Bytes) CopyFrom(b []byte) int {
 	if len(b) > x.length {
 		b = b[:x.length]
@@ -81,6 +203,35 @@ func (x Bytes) CopyTo(b []byte) int {
 	return js.CopyBytesToGo(b, x.v)
 }
 
+// Equal returns true if x and y have the same contents.
+// Values of different length are rejected without touching JS memory; otherwise the cost is that of [Bytes.Compare].
+func (x Bytes) Equal(y Bytes) bool {
+	if x.length != y.length {
+		return false
+	}
+	return x.Compare(y) == 0
+}
+
+// Grow returns a Bytes with the same contents as x and room for at least n more bytes without reallocation.
+// If x already has enough spare capacity, it is returned unchanged. Otherwise the capacity is at least doubled, to amortize repeated growth.
+func (x Bytes) Grow(n int) Bytes {
+	if x.capacity-x.length >= n {
+		return x
+	}
+
+	capacity := 2 * x.capacity
+	if capacity < x.length+n {
+		capacity = x.length + n
+	}
+
+	v := array.New(capacity)
+	if x.length > 0 {
+		v.Call("set", x.v.Call("subarray", 0, x.length))
+	}
+
+	return Bytes{v, x.length, capacity}
+}
+
 func (x Bytes) Js() js.Value {
 	return x.v.Call("subarray", 0, x.length)
 }
@@ -89,8 +240,27 @@ func (x Bytes) Len() int {
 	return x.length
 }
 
+// String returns the contents of x as a string, copying them only once.
+func (x Bytes) String() string {
+	if x.length == 0 {
+		return ""
+	}
+
+	b := make([]byte, x.length)
+	x.CopyTo(b)
+	// b is never modified afterwards
+	return unsafe.String(&b[0], len(b))
+}
+
+// Slice follows Go slicing semantics: the result has length end-start and extends to the capacity of x, so appending to it within
+// capacity overwrites the data following end, as with x[start:end].
+// Panics if the bounds are out of range, that is, unless 0 <= start <= end <= x.Cap().
 func (x Bytes) Slice(start, end int) Bytes {
-	v := x.v.Call("subarray", start)
+	if start < 0 || end < start || end > x.capacity {
+		panic("wasm: Bytes slice bounds out of range [" + strconv.Itoa(start) + ":" + strconv.Itoa(end) + "] with capacity " + strconv.Itoa(x.capacity))
+	}
+
+	v := x.v.Call("subarray", start, x.capacity)
 	return Bytes{v, end - start, x.capacity - start}
 }
 
@@ -124,10 +294,173 @@ func (x *BytesWriter) Close() error {
 }
 
 func (x *BytesWriter) Write(b []byte) (int, error) {
-	x.Dst = x.Dst.Append(b)
+	x.Dst = x.Dst.Grow(len(b)).Append(b)
 	return len(b), nil
 }
 
+// An Error represents a JS exception, as returned by [Await], [Call], [Invoke] and [New].
+// Use errors.As to retrieve it.
+type Error struct {
+	Name    string // such as "TypeError" or "AbortError"; empty if a non-error value was thrown
+	Message string
+	Value   js.Value // the thrown value, for access to custom properties
+}
+
+func (x *Error) Error() string {
+	if x.Name == "" {
+		return x.Message
+	}
+	return x.Name + ": " + x.Message
+}
+
+// Stack returns the JS stack trace, if available.
+func (x *Error) Stack() string {
+	return StringOr(x.property("stack"), "")
+}
+
+// Unwrap returns the cause of x, if it has one.
+func (x *Error) Unwrap() error {
+	cause := x.property("cause")
+	if cause.IsUndefined() || cause.IsNull() {
+		return nil
+	}
+	return errorFrom(cause)
+}
+
+func (x *Error) property(name string) js.Value {
+	if x.Value.Type() != js.TypeObject {
+		return js.Undefined()
+	}
+	return x.Value.Get(name)
+}
+
+// An IdleDeadline describes the time available to an [IdleCallback].
+type IdleDeadline struct {
+	v   js.Value // native deadline; undefined when using the setTimeout fallback
+	end float64  // fallback end time, as performance.now() milliseconds
+}
+
+// DidTimeout returns true if the callback is running because a timeout elapsed, rather than because the browser was idle.
+// Always false for the fallback.
+func (x IdleDeadline) DidTimeout() bool {
+	if x.v.IsUndefined() {
+		return false
+	}
+	return x.v.Get("didTimeout").Bool()
+}
+
+// TimeRemaining returns the estimated number of milliseconds left in the current idle period.
+func (x IdleDeadline) TimeRemaining() float64 {
+	if x.v.IsUndefined() {
+		return max(0, x.end-performance.Call("now").Float())
+	}
+	return x.v.Call("timeRemaining").Float()
+}
+
+// An IdleHandle refers to a pending [IdleCallback].
+type IdleHandle struct {
+	id       js.Value
+	f        js.Func
+	fallback bool
+	pending  bool
+}
+
+// Cancel prevents the callback from running, if it has not already done so.
+// Must be called from event loop.
+func (x *IdleHandle) Cancel() {
+	if !x.pending {
+		return
+	}
+
+	if x.fallback {
+		global.Call("clearTimeout", x.id)
+	} else {
+		global.Call("cancelIdleCallback", x.id)
+	}
+	x.f.Release()
+	x.pending = false
+}
+
+// An Instance wraps an instantiated WebAssembly module.
+type Instance struct {
+	v js.Value
+}
+
+// Exports returns the exported functions, memories and globals of the module.
+func (x Instance) Exports() js.Value {
+	return x.v.Get("exports")
+}
+
+func (x Instance) Js() js.Value {
+	return x.v
+}
+
+// MemStats holds memory usage figures. Unavailable figures are 0.
+type MemStats struct {
+	Linear uint64 // size of the wasm linear memory; requires loading through the glue goLoad function
+
+	// JS heap figures; only available in Chromium based browsers
+	HeapUsed  uint64
+	HeapTotal uint64
+	HeapLimit uint64
+}
+
+// shared pool of Go buffers for copying JS memory, as done by Bytes.Compare
+var bytePool pool
+
+// A pool recycles byte slices. Slices are handled through pointers, so that returning them doesn't allocate.
+type pool struct {
+	p sync.Pool
+}
+
+// get returns a slice of length n. Its contents are undefined.
+func (x *pool) get(n int) *[]byte {
+	if v := x.p.Get(); v != nil {
+		b := v.(*[]byte)
+		if cap(*b) >= n {
+			*b = (*b)[:n]
+			return b
+		}
+	}
+
+	b := make([]byte, n)
+	return &b
+}
+
+// put returns b to the pool. Neither b nor the slice it points to must be used afterwards.
+func (x *pool) put(b *[]byte) {
+	x.p.Put(b)
+}
+
+// An EventTarget wraps any JS object that implements addEventListener, such as DOM nodes, WebSockets or media objects.
+type EventTarget struct {
+	js.Value
+}
+
+// On registers fn as a listener for the named event.
+// The returned function deregisters fn and releases the underlying JS function; it is safe to call more than once.
+func (x EventTarget) On(name string, fn func(js.Value)) func() {
+	f := FuncOf(func(this js.Value, args []js.Value) any {
+		ev := js.Undefined()
+		if len(args) > 0 {
+			ev = args[0]
+		}
+		fn(ev)
+		return nil
+	})
+	x.Call("addEventListener", name, f)
+
+	done := false
+	return func() {
+		if done {
+			return
+		}
+		done = true
+		x.Call("removeEventListener", name, f)
+		f.Release()
+	}
+}
+
 // A Ticker represents a JS Interval. Useful to synchronize with the main JS thread.
 type Ticker struct {
 	v    js.Value
@@ -138,7 +471,7 @@ type Ticker struct {
 func TickerMake(ms uint64, fn func()) Ticker {
 	var o Ticker
 
-	o.f = js.FuncOf(func(this js.Value, args []js.Value) any {
+	o.f = FuncOf(func(this js.Value, args []js.Value) any {
 		if o.done {
 			// if the event has already been queued in the event loop by the time Stop() is called, the JS runtime will still resolve it
 			return nil
@@ -152,6 +485,29 @@ func TickerMake(ms uint64, fn func()) Ticker {
 	return o
 }
 
+// TickerContext calls fn every ms milliseconds, until ctx is done.
+// The interval is cleared and its JS function released on the first tick after ctx is done, so that no queued tick can hit a
+// released function.
+func TickerContext(ctx context.Context, ms uint64, fn func()) {
+	var (
+		f  js.Func
+		id js.Value
+	)
+
+	f = FuncOf(func(this js.Value, args []js.Value) any {
+		if ctx.Err() != nil {
+			global.Call("clearInterval", id)
+			f.Release()
+			return nil
+		}
+
+		fn()
+		return nil
+	})
+
+	id = global.Call("setInterval", f, ms)
+}
+
 // Stop disables the Ticker.
 // Must be called from event loop.
 func (x Ticker) Stop() {
@@ -165,76 +521,184 @@ func (x Ticker) Stop() {
 }
 
 // A Timer represents a JS Timeout. Useful to synchronize with the main JS thread.
+// Copies of a Timer refer to the same timeout. The zero value is an already stopped Timer.
 type Timer struct {
-	v    js.Value
-	f    js.Func
-	done bool
+	*timer
+}
+
+type timer struct {
+	v       js.Value
+	f       js.Func
+	fn      func()
+	pending bool
 }
 
 func TimerMake(ms uint64, fn func()) Timer {
-	var o Timer
+	o := Timer{&timer{fn: fn}}
+	o.schedule(ms)
+	return o
+}
 
-	o.f = js.FuncOf(func(this js.Value, args []js.Value) any {
-		if o.done {
-			return nil
-		}
+// Pending returns true if the Timer has neither fired nor been stopped.
+func (x Timer) Pending() bool {
+	return x.timer != nil && x.pending
+}
 
-		fn()
+// Reset stops the Timer, then schedules it to fire after ms milliseconds. Returns true if the Timer was still pending.
+// Must be called from event loop. Panics on the zero value, which has no function to call.
+func (x Timer) Reset(ms uint64) bool {
+	o := x.Stop()
+	x.schedule(ms)
+	return o
+}
 
-		o.done = true
-		o.f.Release()
+// Stop prevents the Timer from firing, if it has not already done so. Returns true if it did prevent it.
+// Must be called from event loop.
+func (x Timer) Stop() bool {
+	if !x.Pending() {
+		return false
+	}
+
+	global.Call("clearTimeout", x.v)
+	x.f.Release()
+	x.pending = false
+	return true
+}
+
+// schedule sets a new timeout. Each timeout gets its own JS function, which is released either when it fires or when stopped.
+func (x Timer) schedule(ms uint64) {
+	var f js.Func
+	f = FuncOf(func(this js.Value, args []js.Value) any {
+		f.Release()
+		x.pending = false
+		x.fn()
 		return nil
 	})
 
-	o.v = global.Call("setTimeout", o.f, ms)
+	x.f = f
+	x.v = global.Call("setTimeout", f, ms)
+	x.pending = true
+}
 
-	return o
+// AsBool returns the value of v if it is a JS boolean.
+func AsBool(v js.Value) (bool, bool) {
+	if v.Type() != js.TypeBoolean {
+		return false, false
+	}
+	return v.Bool(), true
 }
 
-// Stop prevents the Timer from firing, if it has not already done so.
-// Must be called from event loop.
-func (x Timer) Stop() {
-	if x.done {
-		return
+// AsFloat returns the value of v if it is a JS number.
+func AsFloat(v js.Value) (float64, bool) {
+	if v.Type() != js.TypeNumber {
+		return 0, false
 	}
+	return v.Float(), true
+}
 
-	global.Call("clearTimeout", x.v)
-	x.f.Release()
+// AsInt returns the value of v, truncated towards zero, if it is a JS number.
+func AsInt(v js.Value) (int, bool) {
+	if v.Type() != js.TypeNumber {
+		return 0, false
+	}
+	return v.Int(), true
+}
+
+// AsString returns the value of v if it is a JS string.
+func AsString(v js.Value) (string, bool) {
+	if v.Type() != js.TypeString {
+		return "", false
+	}
+	return v.String(), true
 }
 
 // Await synchronizes the input promise.
+// Returns [ErrEventLoop] if called from a function created through [FuncOf].
 func Await(promise js.Value) (js.Value, error) {
-	resolveCh := make(chan js.Value)
-	resolve := js.FuncOf(func(this js.Value, args []js.Value) any {
+	return AwaitContext(context.Background(), promise)
+}
+
+// AwaitContext is like [Await], but returns ctx.Err() if ctx is done before the promise settles.
+// In that case, the resolution callbacks remain registered, and are released once the promise eventually settles.
+func AwaitContext(ctx context.Context, promise js.Value) (js.Value, error) {
+	if OnEventLoop() {
+		return js.Value{}, ErrEventLoop
+	}
+
+	// buffered, so the callbacks never block, even if nobody is receiving anymore
+	resolveCh := make(chan js.Value, 1)
+	rejectCh := make(chan js.Value, 1)
+
+	var resolve, reject js.Func
+	release := func() {
+		resolve.Release()
+		reject.Release()
+	}
+
+	resolve = js.FuncOf(func(this js.Value, args []js.Value) any {
 		var o js.Value
 		if len(args) > 0 {
 			o = args[0]
 		}
 		resolveCh <- o
+		release()
 		return nil
 	})
-
-	rejectCh := make(chan js.Value)
-	reject := js.FuncOf(func(this js.Value, args []js.Value) any {
-		// there should always be an error when rejecting... right?
-		rejectCh <- args[0]
+	reject = js.FuncOf(func(this js.Value, args []js.Value) any {
+		o := js.Undefined()
+		if len(args) > 0 {
+			o = args[0]
+		}
+		rejectCh <- o
+		release()
 		return nil
 	})
 
 	promise.Call("then", resolve, reject)
-	var o js.Value
-	var err error
 	select {
-	case o = <-resolveCh:
-	case o = <-rejectCh:
-		msg := o.Get("message").String()
-		err = errors.New(msg)
-		o = js.Value{}
+	case o := <-resolveCh:
+		return o, nil
+	case o := <-rejectCh:
+		return js.Value{}, errorFrom(o)
+	case <-ctx.Done():
+		return js.Value{}, ctx.Err()
+	}
+}
+
+// AwaitEvent blocks until target fires the named event, returning the event object.
+// The listener is registered for a single invocation and released afterwards.
+// Must not be called from the event loop; returns [ErrEventLoop] if called from a function created through [FuncOf].
+func AwaitEvent(target js.Value, name string) (js.Value, error) {
+	if OnEventLoop() {
+		return js.Value{}, ErrEventLoop
 	}
 
-	resolve.Release()
-	reject.Release()
-	return o, err
+	ch := make(chan js.Value, 1)
+	fn := js.FuncOf(func(this js.Value, args []js.Value) any {
+		var o js.Value
+		if len(args) > 0 {
+			o = args[0]
+		}
+		ch <- o
+		return nil
+	})
+	defer fn.Release()
+
+	opts := map[string]any{"once": true}
+	if _, err := Call(target, "addEventListener", name, fn, opts); err != nil {
+		return js.Value{}, err
+	}
+
+	return <-ch, nil
+}
+
+// BoolOr returns the value of v if it is a JS boolean, or def otherwise.
+// Unlike js.Value.Bool, it doesn't panic on missing or mistyped values.
+func BoolOr(v js.Value, def bool) bool {
+	if o, ok := AsBool(v); ok {
+		return o
+	}
+	return def
 }
 
 // Call is the method variant of Invoke.
@@ -243,6 +707,22 @@ func Call(obj js.Value, method string, args ...any) (js.Value, error) {
 	return catch(r)
 }
 
+// CallAwait calls the method of obj, then awaits the result if it is a promise.
+// Both synchronous exceptions and rejections are returned as errors.
+// Must not be called from the event loop.
+func CallAwait(obj js.Value, method string, args ...any) (js.Value, error) {
+	v, err := Call(obj, method, args...)
+	if err != nil {
+		return js.Value{}, err
+	}
+
+	// any thenable qualifies
+	if v.Type() == js.TypeObject && v.Get("then").Type() == js.TypeFunction {
+		return Await(v)
+	}
+	return v, nil
+}
+
 func Copy(dst Bytes, src Bytes) {
 	// clip overflow
 	if src.length > dst.length {
@@ -254,12 +734,159 @@ func Copy(dst Bytes, src Bytes) {
 	dst.v.Call("set", v)
 }
 
+// Debounce returns a function that forwards calls to fn only once no further calls have occurred for ms milliseconds, with the
+// most recent argument. Useful for input or resize handlers, for example dom.HandlerMake(wasm.Debounce(300, fn)).
+// Calls are forwarded asynchronously, so event arguments can no longer be cancelled by then.
+// Must be called from event loop.
+func Debounce[T any](ms uint64, fn func(T)) func(T) {
+	var (
+		t    Timer
+		last T
+	)
+
+	return func(a T) {
+		last = a
+		if t.Pending() {
+			t.Reset(ms)
+			return
+		}
+		t = TimerMake(ms, func() {
+			fn(last)
+		})
+	}
+}
+
+// Fetch starts a request through the JS fetch function and returns the promise of its Response, for use with [Await].
+// opts holds the fetch options, such as method, headers, body or signal, and may be nil.
+// Unlike net/http, this exposes the full fetch API, including streaming bodies and request cancellation.
+func Fetch(url string, opts map[string]any) (js.Value, error) {
+	if opts == nil {
+		return Call(global, "fetch", url)
+	}
+	return Call(global, "fetch", url, opts)
+}
+
+// FetchBytes awaits the full body of a fetch Response.
+// Must not be called from the event loop.
+func FetchBytes(resp js.Value) (Bytes, error) {
+	buf, err := CallAwait(resp, "arrayBuffer")
+	if err != nil {
+		return Bytes{}, err
+	}
+	return View(buf), nil
+}
+
+// FloatOr returns the value of v if it is a JS number, or def otherwise.
+// Unlike js.Value.Float, it doesn't panic on missing or mistyped values.
+func FloatOr(v js.Value, def float64) float64 {
+	if o, ok := AsFloat(v); ok {
+		return o
+	}
+	return def
+}
+
+// FuncOf wraps js.FuncOf, keeping track of execution so that [OnEventLoop] can report it.
+// Blocking functions in this module use it to fail instead of deadlocking. All callbacks created by this module and its
+// subpackages that run user code go through it.
+func FuncOf(fn func(this js.Value, args []js.Value) any) js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) any {
+		loopDepth++
+		defer func() {
+			loopDepth--
+		}()
+		return fn(this, args)
+	})
+}
+
+// IdleCallback schedules fn to run when the browser is idle, using requestIdleCallback.
+// Where that is unsupported, falls back to a short setTimeout with a 50ms budget, the maximum the browser would grant.
+// Must be called from event loop.
+func IdleCallback(fn func(deadline IdleDeadline)) *IdleHandle {
+	x := &IdleHandle{
+		fallback: !global.Get("requestIdleCallback").Truthy(),
+		pending:  true,
+	}
+
+	x.f = FuncOf(func(this js.Value, args []js.Value) any {
+		x.f.Release()
+		x.pending = false
+
+		var deadline IdleDeadline
+		if x.fallback {
+			deadline.end = performance.Call("now").Float() + 50
+		} else {
+			deadline.v = args[0]
+		}
+		fn(deadline)
+		return nil
+	})
+
+	if x.fallback {
+		x.id = global.Call("setTimeout", x.f, 1)
+	} else {
+		x.id = global.Call("requestIdleCallback", x.f)
+	}
+	return x
+}
+
+// IntOr returns the value of v if it is a JS number, or def otherwise.
+// Unlike js.Value.Int, it doesn't panic on missing or mistyped values.
+func IntOr(v js.Value, def int) int {
+	if o, ok := AsInt(v); ok {
+		return o
+	}
+	return def
+}
+
 // Invoke exectues a function call, catching a thrown exception and returning it as a Go error.
 func Invoke(fn js.Value, args ...any) (js.Value, error) {
 	r := catchInvoke.Invoke(fn, args)
 	return catch(r)
 }
 
+// JsError converts a Go error to a JS Error, suitable for throwing or rejecting promises.
+// The JS error name is taken from err if it is an [Error], or if it has a Name() string method; otherwise it is "Error".
+func JsError(err error) js.Value {
+	var jsErr *Error
+	if errors.As(err, &jsErr) && jsErr.Name != "" {
+		return JsErrorNamed(jsErr.Name, jsErr.Message)
+	}
+
+	var named interface{ Name() string }
+	if errors.As(err, &named) {
+		return JsErrorNamed(named.Name(), err.Error())
+	}
+
+	return jsErrorType.New(err.Error())
+}
+
+// JsErrorNamed returns a JS Error with the given name, such as "AbortError", for JS code that branches on error names.
+func JsErrorNamed(name, message string) js.Value {
+	o := jsErrorType.New(message)
+	o.Set("name", name)
+	return o
+}
+
+// JsonParse parses s using the native JSON.parse.
+// Returns an error if s is not valid JSON.
+func JsonParse(s string) (js.Value, error) {
+	return Call(json, "parse", s)
+}
+
+// JsonStringify encodes v using the native JSON.stringify.
+// Returns an error if v contains circular references or BigInt values, or if v itself can't be represented, such as a function
+// or undefined.
+func JsonStringify(v js.Value) (string, error) {
+	s, err := Call(json, "stringify", v)
+	if err != nil {
+		return "", err
+	}
+	if s.Type() != js.TypeString {
+		return "", errors.New("value has no JSON representation")
+	}
+	return s.String(), nil
+}
+
 // Keys returns the keys of a JS object.
 func Keys(obj js.Value) []string {
 	if obj.Type() != js.TypeObject {
@@ -276,16 +903,205 @@ func Keys(obj js.Value) []string {
 	return o
 }
 
+// LoadModule fetches, compiles and instantiates the WebAssembly module at url, for plugin style architectures.
+// imports holds the import object expected by the module, and may be nil. Compilation and linking errors are returned as [Error].
+// The server must serve the module with the application/wasm MIME type.
+// Must not be called from the event loop.
+func LoadModule(url string, imports map[string]any) (Instance, error) {
+	resp, err := Fetch(url, nil)
+	if err != nil {
+		return Instance{}, err
+	}
+	if imports == nil {
+		imports = map[string]any{}
+	}
+
+	o, err := CallAwait(global.Get("WebAssembly"), "instantiateStreaming", resp, imports)
+	if err != nil {
+		return Instance{}, err
+	}
+	return Instance{o.Get("instance")}, nil
+}
+
+// LockRequest acquires the named Web Lock, runs fn, then releases the lock.
+// Web Locks are shared by all tabs and workers of the same origin, enabling mutual exclusion or leader election between them.
+// Blocks until the lock is acquired and fn returns. Must not be called from the event loop.
+func LockRequest(name string, fn func()) error {
+	if OnEventLoop() {
+		return ErrEventLoop
+	}
+
+	locks := global.Get("navigator").Get("locks")
+	if locks.IsUndefined() {
+		return errors.New("Web Locks API not available")
+	}
+
+	// the lock is held until the promise returned by the callback settles
+	cb := js.FuncOf(func(this js.Value, args []js.Value) any {
+		exec := js.FuncOf(func(this js.Value, args []js.Value) any {
+			resolve := args[0]
+			go func() {
+				fn()
+				resolve.Invoke()
+			}()
+			return nil
+		})
+		// the executor runs synchronously
+		o := promiseType.New(exec)
+		exec.Release()
+		return o
+	})
+	defer cb.Release()
+
+	promise, err := Call(locks, "request", name, cb)
+	if err != nil {
+		return err
+	}
+	_, err = Await(promise)
+	return err
+}
+
+// MemoryStats returns current memory usage. Useful to detect leaks, such as unreleased functions.
+func MemoryStats() MemStats {
+	var o MemStats
+
+	if mem := global.Get("goMemory"); !mem.IsUndefined() {
+		o.Linear = uint64(mem.Get("buffer").Get("byteLength").Float())
+	}
+
+	if heap := performance.Get("memory"); !heap.IsUndefined() {
+		o.HeapUsed = uint64(heap.Get("usedJSHeapSize").Float())
+		o.HeapTotal = uint64(heap.Get("totalJSHeapSize").Float())
+		o.HeapLimit = uint64(heap.Get("jsHeapSizeLimit").Float())
+	}
+
+	return o
+}
+
 func New(class js.Value, args ...any) (js.Value, error) {
 	r := catchNew.Invoke(class, args)
 	return catch(r)
 }
 
+// OnEventLoop returns true while a function created through [FuncOf] is executing, meaning the event loop is waiting on it.
+// Functions created directly through js.FuncOf are not tracked.
+//
+// The state is global, not per goroutine: while a tracked function is blocked, such as on a channel send, OnEventLoop also
+// returns true in every other goroutine, so a worker calling [Await] gets a spurious [ErrEventLoop]. Tracked functions should
+// hand off work without blocking, for example through a buffered channel or a new goroutine.
+func OnEventLoop() bool {
+	return loopDepth > 0
+}
+
+// OnError routes uncaught JS errors to fn, replacing any previously set handler.
+// fn receives the thrown value, or the error message if none is available.
+// A nil fn removes the handler.
+func OnError(fn func(js.Value)) {
+	onError.Release()
+	if fn == nil {
+		global.Set("onerror", js.Null())
+		return
+	}
+
+	onError = FuncOf(func(this js.Value, args []js.Value) any {
+		// arguments are: message, source, line, column, error
+		v := args[0]
+		if len(args) > 4 && !args[4].IsUndefined() {
+			v = args[4]
+		}
+		fn(v)
+		return nil
+	})
+	global.Set("onerror", onError)
+}
+
+// OnUnhandledRejection routes the reasons of unhandled promise rejections to fn, replacing any previously set handler.
+// A nil fn removes the handler.
+func OnUnhandledRejection(fn func(js.Value)) {
+	onRejection.Release()
+	if fn == nil {
+		global.Set("onunhandledrejection", js.Null())
+		return
+	}
+
+	onRejection = FuncOf(func(this js.Value, args []js.Value) any {
+		fn(args[0].Get("reason"))
+		return nil
+	})
+	global.Set("onunhandledrejection", onRejection)
+}
+
 // Print uses the console.log function to print JS values.
 func Print(v js.Value) {
 	console.Call("log", v)
 }
 
+// StringOr returns the value of v if it is a JS string, or def otherwise.
+// Unlike js.Value.String, it doesn't format other types, such as "<undefined>".
+func StringOr(v js.Value, def string) string {
+	if o, ok := AsString(v); ok {
+		return o
+	}
+	return def
+}
+
+// StructuredClone returns a deep copy of v, using the structured clone algorithm.
+// Returns an error if v contains values that can't be cloned, such as functions or DOM nodes.
+func StructuredClone(v js.Value) (js.Value, error) {
+	return Invoke(clone, v)
+}
+
+// Throttle returns a function that forwards calls to fn at most once every ms milliseconds.
+// The first call is forwarded immediately. Calls made while throttled are collapsed into a single trailing call with the most
+// recent argument, forwarded at the end of the interval.
+// Must be called from event loop.
+func Throttle[T any](ms uint64, fn func(T)) func(T) {
+	var (
+		t      Timer
+		last   T
+		queued bool
+		tick   func()
+	)
+
+	tick = func() {
+		if !queued {
+			return
+		}
+		queued = false
+		fn(last)
+		t = TimerMake(ms, tick)
+	}
+
+	return func(a T) {
+		if t.Pending() {
+			last = a
+			queued = true
+			return
+		}
+		fn(a)
+		t = TimerMake(ms, tick)
+	}
+}
+
+// ValueOf is like js.ValueOf, but returns an error instead of panicking on unsupported types.
+// Additionally, Bytes and []byte values are converted to Uint8Array.
+func ValueOf(v any) (o js.Value, err error) {
+	switch t := v.(type) {
+	case Bytes:
+		return t.Js(), nil
+	case []byte:
+		return BytesOf(t).Js(), nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			o = js.Value{}
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return js.ValueOf(v), nil
+}
+
 func catch(v js.Value) (js.Value, error) {
 	if v.Index(0).Bool() {
 		return js.Undefined(), errorFrom(v.Index(1))
@@ -295,8 +1111,14 @@ func catch(v js.Value) (js.Value, error) {
 }
 
 func errorFrom(v js.Value) error {
-	errStr := v.Get("name").String()
-	errStr += ": " + v.Get("message").String()
+	if v.Type() != js.TypeObject {
+		// anything can be thrown; convert as JS would display it, rather than js.Value's "<number: 42>" form
+		return &Error{Message: stringType.Invoke(v).String(), Value: v}
+	}
 
-	return errors.New(errStr)
+	return &Error{
+		Name:    StringOr(v.Get("name"), ""),
+		Message: StringOr(v.Get("message"), ""),
+		Value:   v,
+	}
 }
@@ -0,0 +1,330 @@
+package wasm
+
+import (
+	"errors"
+	"syscall/js"
+	"testing"
+	"time"
+)
+
+func TestBytesCompare(t *testing.T) {
+	cases := []struct {
+		x, y string
+		out  int
+	}{
+		{"", "", 0},
+		{"", "a", -1},
+		{"abc", "abc", 0},
+		{"abc", "abd", -1},
+		{"abd", "abc", 1},
+		{"ab", "abc", -1},
+	}
+
+	for _, c := range cases {
+		x, y := BytesOfString(c.x), BytesOfString(c.y)
+		if o := x.Compare(y); o != c.out {
+			t.Errorf("%q, %q: got %d, want %d", c.x, c.y, o, c.out)
+		}
+		if eq := x.Equal(y); eq != (c.out == 0) {
+			t.Errorf("%q, %q: Equal returned %v", c.x, c.y, eq)
+		}
+	}
+
+	x, y := BytesOfString("hello world"), BytesOfString("hello there")
+	x.Compare(y)
+	if n := testing.AllocsPerRun(100, func() { x.Compare(y) }); n != 0 {
+		t.Errorf("got %v allocations per Compare, want 0", n)
+	}
+}
+
+func TestBytesGrow(t *testing.T) {
+	x := BytesOfString("abc").Grow(10)
+	if x.Len() != 3 || x.Cap() < 13 {
+		t.Errorf("got length %d, capacity %d; want 3, at least 13", x.Len(), x.Cap())
+	}
+	if s := x.String(); s != "abc" {
+		t.Errorf("got contents %q, want %q", s, "abc")
+	}
+
+	// enough spare capacity: unchanged
+	if y := x.Grow(x.Cap() - x.Len()); y.Cap() != x.Cap() || !y.v.Equal(x.v) {
+		t.Error("reallocated despite spare capacity")
+	}
+
+	// capacity at least doubles
+	if c := BytesMake(8, 8).Grow(1).Cap(); c != 16 {
+		t.Errorf("got capacity %d, want 16", c)
+	}
+
+	if y := (Bytes{}).Grow(4); y.Len() != 0 || y.Cap() != 4 {
+		t.Errorf("zero value: got length %d, capacity %d; want 0, 4", y.Len(), y.Cap())
+	}
+}
+
+func TestBytesSlice(t *testing.T) {
+	x := BytesOfString("01234567")
+	y := x.Slice(2, 5)
+	if y.Len() != 3 || y.Cap() != 6 {
+		t.Fatalf("got length %d, capacity %d; want 3, 6", y.Len(), y.Cap())
+	}
+	if s := y.String(); s != "234" {
+		t.Errorf("got contents %q, want %q", s, "234")
+	}
+
+	// within capacity, appending overwrites the data following end, as in Go
+	z := y.Append([]byte("ab"))
+	if s := z.String(); s != "234ab" {
+		t.Errorf("got appended contents %q, want %q", s, "234ab")
+	}
+	if s := x.String(); s != "01234ab7" {
+		t.Errorf("got original contents %q, want %q", s, "01234ab7")
+	}
+
+	// past capacity, appending reallocates and leaves x alone
+	z = y.Append([]byte("abcd"))
+	if s := z.String(); s != "234abcd" {
+		t.Errorf("got reallocated contents %q, want %q", s, "234abcd")
+	}
+	if s := x.String(); s != "01234ab7" {
+		t.Errorf("original modified by reallocating append: %q", s)
+	}
+
+	if y := x.Slice(8, 8); y.Len() != 0 || y.Cap() != 0 {
+		t.Errorf("empty slice at end: got length %d, capacity %d", y.Len(), y.Cap())
+	}
+
+	for _, c := range [][2]int{{-1, 2}, {3, 2}, {0, 9}, {9, 9}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Slice(%d, %d) did not panic", c[0], c[1])
+				}
+			}()
+			x.Slice(c[0], c[1])
+		}()
+	}
+}
+
+func TestBytesString(t *testing.T) {
+	cases := []string{
+		"",
+		"ascii",
+		"héllo wörld",
+		"日本語のテキスト",
+		"emoji 🌍🚀 and combining e\u0301",
+		"\x00\xff\xfe invalid utf-8",
+	}
+
+	for _, s := range cases {
+		x := BytesOfString(s)
+		if x.Len() != len(s) {
+			t.Errorf("%q: got length %d, want %d", s, x.Len(), len(s))
+		}
+		if o := x.String(); o != s {
+			t.Errorf("got %q, want %q", o, s)
+		}
+		if o := BytesOf([]byte(s)).String(); o != s {
+			t.Errorf("BytesOf: got %q, want %q", o, s)
+		}
+	}
+}
+
+func TestBytesWriter(t *testing.T) {
+	w := BytesWriter{Dst: BytesMake(0, 0)}
+	want := make([]byte, 0, 1000)
+
+	grows := 0
+	for i := 0; i < 1000; i++ {
+		c := w.Dst.Cap()
+		b := []byte{byte(i)}
+		w.Write(b)
+		want = append(want, b...)
+		if w.Dst.Cap() != c {
+			grows++
+		}
+	}
+
+	// doubling from 1 reaches 1000 in 11 steps
+	if grows > 11 {
+		t.Errorf("reallocated %d times, want at most 11", grows)
+	}
+	if s := w.Dst.String(); s != string(want) {
+		t.Error("written contents differ")
+	}
+}
+
+func TestDebounce(t *testing.T) {
+	calls := make(chan int, 4)
+	fn := Debounce(20, func(v int) { calls <- v })
+
+	for i := 1; i <= 3; i++ {
+		fn(i)
+	}
+
+	// a single trailing call, with the last argument
+	if v := waitCall(t, calls); v != 3 {
+		t.Errorf("got argument %d, want 3", v)
+	}
+	noCall(t, calls)
+}
+
+func TestErrorFrom(t *testing.T) {
+	cases := []struct {
+		v             any
+		name, message string
+	}{
+		{jsErrorType.New("boom"), "Error", "boom"},
+		{JsErrorNamed("AbortError", "canceled"), "AbortError", "canceled"},
+		{42, "", "42"},
+		{"boom", "", "boom"},
+		{true, "", "true"},
+		{nil, "", "null"},
+		{js.Undefined(), "", "undefined"},
+	}
+
+	for _, c := range cases {
+		v := js.ValueOf(c.v)
+		var jsErr *Error
+		if !errors.As(errorFrom(v), &jsErr) {
+			t.Fatalf("%v: not an *Error", c.v)
+		}
+		if jsErr.Name != c.name || jsErr.Message != c.message {
+			t.Errorf("%v: got %q, %q; want %q, %q", c.v, jsErr.Name, jsErr.Message, c.name, c.message)
+		}
+		if !jsErr.Value.Equal(v) {
+			t.Errorf("%v: thrown value not retained", c.v)
+		}
+	}
+}
+
+func TestErrorUnwrap(t *testing.T) {
+	cause := JsErrorNamed("TypeError", "inner")
+	err := errorFrom(jsErrorType.New("outer", map[string]any{"cause": cause}))
+
+	var jsErr *Error
+	if !errors.As(err, &jsErr) || jsErr.Message != "outer" {
+		t.Fatalf("got %v, want outer error", err)
+	}
+	inner, ok := errors.Unwrap(err).(*Error)
+	if !ok || inner.Name != "TypeError" || inner.Message != "inner" {
+		t.Fatalf("got cause %v, want TypeError: inner", errors.Unwrap(err))
+	}
+
+	for _, v := range []any{nil, js.Undefined()} {
+		err := errorFrom(jsErrorType.New("outer", map[string]any{"cause": v}))
+		if cause := errors.Unwrap(err); cause != nil {
+			t.Errorf("cause %v: got %v, want nil", v, cause)
+		}
+	}
+	if cause := errors.Unwrap(errorFrom(jsErrorType.New("outer"))); cause != nil {
+		t.Errorf("no cause: got %v, want nil", cause)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	calls := make(chan int, 4)
+	fn := Throttle(20, func(v int) { calls <- v })
+
+	for i := 1; i <= 3; i++ {
+		fn(i)
+	}
+
+	// the leading call is forwarded immediately
+	select {
+	case v := <-calls:
+		if v != 1 {
+			t.Errorf("leading call: got argument %d, want 1", v)
+		}
+	default:
+		t.Fatal("leading call not forwarded immediately")
+	}
+
+	// followed by a single trailing call, with the last argument
+	if v := waitCall(t, calls); v != 3 {
+		t.Errorf("trailing call: got argument %d, want 3", v)
+	}
+	noCall(t, calls)
+}
+
+func TestTimer(t *testing.T) {
+	var zero Timer
+	if zero.Pending() || zero.Stop() {
+		t.Error("zero value is not stopped")
+	}
+
+	fired := make(chan struct{}, 4)
+	fn := func() { fired <- struct{}{} }
+
+	// fires
+	x := TimerMake(10, fn)
+	if !x.Pending() {
+		t.Error("new Timer is not pending")
+	}
+	waitFired(t, fired, time.Second)
+	if x.Pending() {
+		t.Error("pending after firing")
+	}
+	if x.Stop() {
+		t.Error("Stop returned true after firing")
+	}
+
+	// stopped before firing
+	x = TimerMake(10, fn)
+	if !x.Stop() {
+		t.Error("Stop returned false before firing")
+	}
+	if x.Stop() {
+		t.Error("second Stop returned true")
+	}
+	select {
+	case <-fired:
+		t.Error("fired after Stop")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Reset moves a pending Timer
+	x = TimerMake(10000, fn)
+	if !x.Reset(10) {
+		t.Error("Reset returned false for pending Timer")
+	}
+	waitFired(t, fired, time.Second)
+
+	// Reset reschedules a fired Timer
+	if x.Reset(10) {
+		t.Error("Reset returned true for fired Timer")
+	}
+	if !x.Pending() {
+		t.Error("not pending after Reset")
+	}
+	waitFired(t, fired, time.Second)
+}
+
+// noCall fails if ch receives anything within a few intervals.
+func noCall(t *testing.T, ch <-chan int) {
+	t.Helper()
+	select {
+	case v := <-ch:
+		t.Errorf("unexpected call with argument %d", v)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func waitCall(t *testing.T, ch <-chan int) int {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(time.Second):
+		t.Fatal("not called in time")
+		return 0
+	}
+}
+
+func waitFired(t *testing.T, ch <-chan struct{}, d time.Duration) {
+	t.Helper()
+	select {
+	case <-ch:
+	case <-time.After(d):
+		t.Fatal("did not fire in time")
+	}
+}
@@ -0,0 +1,249 @@
+// Package webgl wraps the core of the JS WebGL API.
+package webgl
+
+import (
+	"errors"
+	"syscall/js"
+	"unsafe"
+
+	"github.com/blitz-frost/wasm"
+)
+
+var float32Array = js.Global().Get("Float32Array")
+
+// buffer targets
+const (
+	ArrayBuffer        Enum = 0x8892
+	ElementArrayBuffer Enum = 0x8893
+)
+
+// buffer usages
+const (
+	StaticDraw  Enum = 0x88E4
+	StreamDraw  Enum = 0x88E0
+	DynamicDraw Enum = 0x88E8
+)
+
+// clear masks
+const (
+	ColorBufferBit   Enum = 0x4000
+	DepthBufferBit   Enum = 0x0100
+	StencilBufferBit Enum = 0x0400
+)
+
+// data types
+const (
+	Byte          Enum = 0x1400
+	UnsignedByte  Enum = 0x1401
+	Short         Enum = 0x1402
+	UnsignedShort Enum = 0x1403
+	Float         Enum = 0x1406
+)
+
+// capabilities
+const (
+	Blend     Enum = 0x0BE2
+	CullFace  Enum = 0x0B44
+	DepthTest Enum = 0x0B71
+)
+
+// primitives
+const (
+	Points        Enum = 0x0000
+	Lines         Enum = 0x0001
+	LineLoop      Enum = 0x0002
+	LineStrip     Enum = 0x0003
+	Triangles     Enum = 0x0004
+	TriangleStrip Enum = 0x0005
+	TriangleFan   Enum = 0x0006
+)
+
+// shader kinds
+const (
+	FragmentShader Enum = 0x8B30
+	VertexShader   Enum = 0x8B31
+)
+
+const (
+	compileStatus Enum = 0x8B81
+	linkStatus    Enum = 0x8B82
+)
+
+type Buffer struct {
+	v js.Value
+}
+
+// An Enum is a WebGL constant.
+type Enum uint32
+
+// A GL wraps a WebGLRenderingContext.
+type GL struct {
+	v js.Value
+}
+
+// AsGL wraps an existing WebGL context. Returns an error if v is null, as returned by getContext when WebGL is unavailable.
+func AsGL(v js.Value) (*GL, error) {
+	if v.IsNull() || v.IsUndefined() {
+		return nil, errors.New("WebGL not available")
+	}
+	return &GL{v}, nil
+}
+
+// AttribLocation returns the location of the named attribute, or -1 if p has no such attribute.
+func (x *GL) AttribLocation(p Program, name string) int {
+	return x.v.Call("getAttribLocation", p.v, name).Int()
+}
+
+func (x *GL) AttachShader(p Program, s Shader) {
+	x.v.Call("attachShader", p.v, s.v)
+}
+
+func (x *GL) BindBuffer(target Enum, b Buffer) {
+	x.v.Call("bindBuffer", uint32(target), b.v)
+}
+
+// BufferData uploads data to the buffer currently bound to target.
+func (x *GL) BufferData(target Enum, data []float32, usage Enum) {
+	x.v.Call("bufferData", uint32(target), float32Of(data), uint32(usage))
+}
+
+func (x *GL) Clear(mask Enum) {
+	x.v.Call("clear", uint32(mask))
+}
+
+func (x *GL) ClearColor(r, g, b, a float32) {
+	x.v.Call("clearColor", r, g, b, a)
+}
+
+// CompileShader compiles s, returning the info log as an error on failure.
+func (x *GL) CompileShader(s Shader) error {
+	x.v.Call("compileShader", s.v)
+	if !x.v.Call("getShaderParameter", s.v, uint32(compileStatus)).Bool() {
+		return errors.New(x.v.Call("getShaderInfoLog", s.v).String())
+	}
+	return nil
+}
+
+func (x *GL) CreateBuffer() Buffer {
+	return Buffer{x.v.Call("createBuffer")}
+}
+
+func (x *GL) CreateProgram() Program {
+	return Program{x.v.Call("createProgram")}
+}
+
+func (x *GL) CreateShader(kind Enum) Shader {
+	return Shader{x.v.Call("createShader", uint32(kind))}
+}
+
+func (x *GL) DeleteBuffer(b Buffer) {
+	x.v.Call("deleteBuffer", b.v)
+}
+
+func (x *GL) DeleteProgram(p Program) {
+	x.v.Call("deleteProgram", p.v)
+}
+
+func (x *GL) DeleteShader(s Shader) {
+	x.v.Call("deleteShader", s.v)
+}
+
+func (x *GL) Disable(cap Enum) {
+	x.v.Call("disable", uint32(cap))
+}
+
+func (x *GL) DrawArrays(mode Enum, first, count int) {
+	x.v.Call("drawArrays", uint32(mode), first, count)
+}
+
+func (x *GL) Enable(cap Enum) {
+	x.v.Call("enable", uint32(cap))
+}
+
+func (x *GL) EnableVertexAttribArray(index int) {
+	x.v.Call("enableVertexAttribArray", index)
+}
+
+func (x *GL) Js() js.Value {
+	return x.v
+}
+
+// LinkProgram links p, returning the info log as an error on failure.
+func (x *GL) LinkProgram(p Program) error {
+	x.v.Call("linkProgram", p.v)
+	if !x.v.Call("getProgramParameter", p.v, uint32(linkStatus)).Bool() {
+		return errors.New(x.v.Call("getProgramInfoLog", p.v).String())
+	}
+	return nil
+}
+
+func (x *GL) ShaderSource(s Shader, src string) {
+	x.v.Call("shaderSource", s.v, src)
+}
+
+func (x *GL) Uniform1f(loc Uniform, v0 float32) {
+	x.v.Call("uniform1f", loc.v, v0)
+}
+
+func (x *GL) Uniform1i(loc Uniform, v0 int) {
+	x.v.Call("uniform1i", loc.v, v0)
+}
+
+func (x *GL) Uniform2f(loc Uniform, v0, v1 float32) {
+	x.v.Call("uniform2f", loc.v, v0, v1)
+}
+
+func (x *GL) Uniform3f(loc Uniform, v0, v1, v2 float32) {
+	x.v.Call("uniform3f", loc.v, v0, v1, v2)
+}
+
+func (x *GL) Uniform4f(loc Uniform, v0, v1, v2, v3 float32) {
+	x.v.Call("uniform4f", loc.v, v0, v1, v2, v3)
+}
+
+// UniformLocation returns the location of the named uniform. The result is invalid if p has no such uniform.
+func (x *GL) UniformLocation(p Program, name string) Uniform {
+	return Uniform{x.v.Call("getUniformLocation", p.v, name)}
+}
+
+// UniformMatrix4f sets a 4x4 matrix uniform, given in column major order.
+func (x *GL) UniformMatrix4f(loc Uniform, m [16]float32) {
+	x.v.Call("uniformMatrix4fv", loc.v, false, float32Of(m[:]))
+}
+
+func (x *GL) UseProgram(p Program) {
+	x.v.Call("useProgram", p.v)
+}
+
+// VertexAttribPointer describes the layout of the attribute at index, within the buffer currently bound to ArrayBuffer.
+// stride and offset are in bytes.
+func (x *GL) VertexAttribPointer(index, size int, kind Enum, normalized bool, stride, offset int) {
+	x.v.Call("vertexAttribPointer", index, size, uint32(kind), normalized, stride, offset)
+}
+
+func (x *GL) Viewport(left, bottom, width, height int) {
+	x.v.Call("viewport", left, bottom, width, height)
+}
+
+type Program struct {
+	v js.Value
+}
+
+type Shader struct {
+	v js.Value
+}
+
+type Uniform struct {
+	v js.Value
+}
+
+// float32Of copies data into a new Float32Array.
+func float32Of(data []float32) js.Value {
+	if len(data) == 0 {
+		return float32Array.New(0)
+	}
+
+	// wasm is little endian, same as JS typed arrays on every relevant platform
+	b := unsafe.Slice((*byte)(unsafe.Pointer(&data[0])), len(data)*4)
+	return float32Array.New(wasm.BytesOf(b).Js().Get("buffer"))
+}